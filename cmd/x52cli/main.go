@@ -20,6 +20,7 @@ func main() {
 
 	rootCmd.AddCommand(ledCommand)
 	rootCmd.AddCommand(mfdCommand)
+	rootCmd.AddCommand(serveCommand)
 	rootCmd.Execute()
 }
 
@@ -2,7 +2,10 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"nirenjan.org/saitek-x52/x52/util"
@@ -12,6 +15,10 @@ var mfdCommand *cobra.Command
 
 var mfdTextReplace bool
 var mfdTextReplChar byte
+var mfdTextTranslit bool
+var mfdTextKana bool
+var mfdTextCharmap string
+var mfdTextPreview string
 
 func init() {
 	mfdCommand = &cobra.Command{
@@ -26,11 +33,22 @@ string can be specified as '' or "".
 The given string is translated into the character map of the X52
 display. The options --replace and --replacement-byte control
 whether characters that are not recognized by the display are shown
-or not, and if shown, which byte in the character map to use.
+or not, and if shown, which byte in the character map to use. The
+option --transliterate converts such characters to a close match,
+such as by removing accents, before falling back to the replacement.
+The option --kana converts hiragana and full-width katakana to the
+half-width katakana supported by the display. The option --charmap
+loads additional character mappings from the given file, with each
+line in the form "<codepoint>: <byte>", both in hex.
 
 If the translated string exceeds the line length, then it is
 silently truncated.
 
+The option --preview renders the line to the given file instead of
+sending it to the joystick, so that no hardware is required. The
+other lines are shown blank. The file is written in SVG format if
+its name ends in .svg, and in PNG format otherwise.
+
 `,
 		Args: cobra.ExactArgs(2),
 		RunE: setMFDText,
@@ -38,6 +56,10 @@ silently truncated.
 
 	mfdCommand.Flags().BoolVar(&mfdTextReplace, "replace", false, "replace unknown characters")
 	mfdCommand.Flags().Uint8Var(&mfdTextReplChar, "replacement-byte", util.ReplaceMissing, "replacement byte")
+	mfdCommand.Flags().BoolVar(&mfdTextTranslit, "transliterate", false, "transliterate unknown characters")
+	mfdCommand.Flags().BoolVar(&mfdTextKana, "kana", false, "convert hiragana and full-width katakana")
+	mfdCommand.Flags().StringVar(&mfdTextCharmap, "charmap", "", "load character map overrides from `FILE`")
+	mfdCommand.Flags().StringVar(&mfdTextPreview, "preview", "", "render the line to `FILE` instead of the joystick")
 }
 
 func setMFDText(_ *cobra.Command, args []string) error {
@@ -53,6 +75,30 @@ func setMFDText(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("Line %v is outside the range [1, 3]", line)
 	}
 
+	charmap := util.NewCharmap()
+	if mfdTextCharmap != "" {
+		if err := charmap.LoadFile(mfdTextCharmap); err != nil {
+			return err
+		}
+	}
+
+	var flags util.ConvertFlags
+	if mfdTextReplace {
+		flags |= util.ConvertReplace
+	}
+	if mfdTextTranslit {
+		flags |= util.ConvertTransliterate
+	}
+	if mfdTextKana {
+		flags |= util.ConvertKana
+	}
+
+	data := charmap.Convert(args[1], flags, mfdTextReplChar)
+
+	if mfdTextPreview != "" {
+		return writeMFDPreview(mfdTextPreview, line, data)
+	}
+
 	ctx := connectToX52()
 	defer ctx.Close()
 
@@ -60,9 +106,35 @@ func setMFDText(_ *cobra.Command, args []string) error {
 		fmt.Printf("Setting MFD line %v to %q\n", line, args[1])
 	}
 
-	data := util.ConvertStringToX52Charmap(args[1], mfdTextReplace, mfdTextReplChar)
-	ctx.SetMFDText(uint8(line), data)
+	ctx.SetMFDText(uint8(line-1), data)
 	ctx.Update()
 
 	return nil
 }
+
+// writeMFDPreview renders the MFD with the given line set to the named file
+func writeMFDPreview(name string, line int, data []byte) error {
+	lines := make([][]byte, 3)
+	lines[line-1] = data
+
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(filepath.Ext(name), ".svg") {
+		err = util.RenderSVG(f, lines, nil)
+	} else {
+		err = util.RenderPNG(f, lines, nil)
+	}
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	if cliVerbose {
+		fmt.Printf("Wrote preview of MFD line %v to %v\n", line, name)
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/spf13/cobra"
+	"nirenjan.org/saitek-x52/x52/sim"
+)
+
+var serveCommand *cobra.Command
+
+func init() {
+	serveCommand = &cobra.Command{
+		Use:   "serve <address>",
+		Short: "Serve the joystick to other machines",
+		Long: `Serve the LEDs and MFD of the connected joystick to programs on
+other machines. The address is a TCP address in the form
+tcp:host:port, or the path to a Unix domain socket.
+
+Programs using the library connect to the joystick by setting the
+X52_SIMULATOR environment variable to the same address, with the
+host set to this machine. The connections are not authenticated,
+so the address should only be reachable from trusted machines.
+
+Only the output of the joystick is served. The buttons and axes
+are not sent to the other machine.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: serveX52,
+	}
+}
+
+func serveX52(_ *cobra.Command, args []string) error {
+	network, address := sim.ParseAddr(args[0])
+	ln, err := net.Listen(network, address)
+	if err != nil {
+		return err
+	}
+	defer ln.Close()
+
+	ctx := connectToX52()
+	defer ctx.Close()
+
+	if cliVerbose {
+		fmt.Printf("Serving joystick on %v\n", ln.Addr())
+	}
+
+	return ctx.Serve(ln)
+}
@@ -0,0 +1,7 @@
+module nirenjan.org/saitek-x52/cmd/x52sim
+
+go 1.14
+
+require nirenjan.org/saitek-x52 v0.0.0-00010101000000-000000000000
+
+replace nirenjan.org/saitek-x52 => ../..
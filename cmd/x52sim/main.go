@@ -0,0 +1,148 @@
+// x52sim is a terminal program that simulates an X52 Pro joystick. It shows
+// the MFD text, clocks, shift and blink indicators and the LEDs, as they are
+// updated by programs using the x52 library.
+//
+// Run x52sim, and set the X52_SIMULATOR environment variable to the socket
+// path it prints, before running x52cli, x52test or any other program using
+// the library.
+package main // import "nirenjan.org/saitek-x52/cmd/x52sim"
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"nirenjan.org/saitek-x52/x52/sim"
+)
+
+// simulator holds the simulated joystick, which is shared by every client
+type simulator struct {
+	mu      sync.Mutex
+	path    string
+	state   sim.State
+	clients int
+	lastErr error
+	changed chan struct{}
+}
+
+func main() {
+	defaultPath := os.Getenv(sim.Env)
+	if defaultPath == "" {
+		defaultPath = filepath.Join(os.TempDir(), "x52sim.sock")
+	}
+
+	var path string
+	flag.StringVar(&path, "socket", defaultPath, "path to the simulator `socket`")
+	flag.Parse()
+
+	if err := removeStaleSocket(path); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer ln.Close()
+
+	s := &simulator{
+		path:    path,
+		changed: make(chan struct{}, 1),
+	}
+	go s.serve(ln)
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+
+	// Hide the cursor while running, and restore it on exit
+	fmt.Print("\x1b[?25l")
+	defer fmt.Print("\x1b[?25h\n")
+
+	// The display is redrawn on every change, and periodically so that the
+	// blinking POV LED can be shown
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	blink := false
+	for {
+		s.render(os.Stdout, blink)
+
+		select {
+		case <-sigs:
+			return
+		case <-s.changed:
+		case <-ticker.C:
+			blink = !blink
+		}
+	}
+}
+
+// removeStaleSocket removes a socket left behind by a previous run. Anything
+// other than a socket at the path is left alone, so that a mistyped path does
+// not delete a regular file.
+func removeStaleSocket(path string) error {
+	fi, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if fi.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%v exists and is not a socket", path)
+	}
+	return os.Remove(path)
+}
+
+// serve accepts connections from the library until the listener is closed
+func (s *simulator) serve(ln net.Listener) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+// handle applies the packets from a single connection to the simulator state
+func (s *simulator) handle(conn net.Conn) {
+	defer conn.Close()
+
+	s.update(func() { s.clients++ })
+	defer s.update(func() { s.clients-- })
+
+	for {
+		p, err := sim.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+
+		s.update(func() {
+			if err := s.state.Apply(p); err != nil {
+				s.lastErr = err
+			}
+		})
+	}
+}
+
+// update runs f with the simulator locked, and triggers a redraw
+func (s *simulator) update(f func()) {
+	s.mu.Lock()
+	f()
+	s.mu.Unlock()
+
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"nirenjan.org/saitek-x52/x52/sim"
+	"nirenjan.org/saitek-x52/x52/util"
+)
+
+// ANSI escape sequences used by the display
+const (
+	ansiHome    = "\x1b[H"
+	ansiClear   = "\x1b[J"
+	ansiEOL     = "\x1b[K"
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiDim     = "\x1b[2m"
+	ansiReverse = "\x1b[7m"
+	ansiRed     = "\x1b[1;31m"
+	ansiGreen   = "\x1b[1;32m"
+	ansiAmber   = "\x1b[1;33m"
+	ansiWhite   = "\x1b[1;37m"
+)
+
+// render draws the simulator state to the terminal. When blink is true and
+// blinking is enabled, the POV LED is drawn as off.
+func (s *simulator) render(w io.Writer, blink bool) {
+	s.mu.Lock()
+	state := s.state.Clone()
+	clients := s.clients
+	lastErr := s.lastErr
+	s.mu.Unlock()
+
+	var buf bytes.Buffer
+	line := func(format string, a ...interface{}) {
+		fmt.Fprintf(&buf, format, a...)
+		buf.WriteString(ansiEOL + "\n")
+	}
+
+	buf.WriteString(ansiHome)
+	line("%sX52 Pro simulator%s - %s=%s, %d connected", ansiBold, ansiReset, sim.Env, s.path, clients)
+	line("")
+
+	side := []string{
+		"Clock 1  " + clockText(&state, 0),
+		"Clock 2  " + clockText(&state, 1),
+		"Clock 3  " + clockText(&state, 2),
+		"Date     " + dateText(&state),
+		indicator("SHIFT", state.Shift) + " " + indicator("BLINK", state.Blink),
+	}
+
+	line("  ┌%s┐   %s", strings.Repeat("─", sim.LineSize), side[0])
+	for i := 0; i < sim.Lines; i++ {
+		line("  │%s│   %s", mfdText(state.Lines[i]), side[i+1])
+	}
+	line("  └%s┘   %s", strings.Repeat("─", sim.LineSize), side[4])
+	line("")
+
+	var leds []string
+	for _, led := range state.LEDs() {
+		color := led.Color
+		if led.Name == "POV" && state.Blink && blink {
+			color = sim.ColorOff
+		}
+		leds = append(leds, led.Name+" "+ledText(color))
+	}
+	line("  %s", strings.Join(leds, "  "))
+	line("")
+	line("  MFD brightness %d   LED brightness %d", state.MFDBrightness, state.LEDBrightness)
+
+	if lastErr != nil {
+		line("  %s%v%s", ansiRed, lastErr, ansiReset)
+	}
+
+	buf.WriteString(ansiClear)
+	w.Write(buf.Bytes())
+}
+
+// mfdText returns the MFD line as a string, padded to the line length
+func mfdText(data []byte) string {
+	var text [sim.LineSize]byte
+	for i := range text {
+		text[i] = ' '
+	}
+
+	// The library pads lines with an odd length with a NUL byte, which is
+	// not displayed
+	for i, c := range data {
+		if i < len(text) && c != 0 {
+			text[i] = c
+		}
+	}
+
+	return util.DecodeX52Charmap(text[:])
+}
+
+// clockText returns the time on the clock, in the clock's format
+func clockText(state *sim.State, clock int) string {
+	hour, minute, ok := state.ClockTime(clock)
+	if !ok {
+		return "--:--"
+	}
+
+	if state.Clocks[clock].Hour24 {
+		return fmt.Sprintf("%02d:%02d", hour, minute)
+	}
+
+	suffix := "AM"
+	if hour >= 12 {
+		suffix = "PM"
+	}
+
+	hour %= 12
+	if hour == 0 {
+		hour = 12
+	}
+
+	return fmt.Sprintf("%02d:%02d %s", hour, minute, suffix)
+}
+
+// dateText returns the date fields in the order they are displayed. The MFD
+// only has two digits for each field.
+func dateText(state *sim.State) string {
+	if !state.DateSet {
+		return "--/--/--"
+	}
+
+	return fmt.Sprintf("%02d/%02d/%02d", state.Date[0]%100, state.Date[1]%100, state.Date[2]%100)
+}
+
+// indicator returns the label highlighted if the indicator is on
+func indicator(label string, on bool) string {
+	if on {
+		return ansiReverse + label + ansiReset
+	}
+
+	return ansiDim + label + ansiReset
+}
+
+// ledText returns a colored symbol for the LED
+func ledText(color sim.LEDColor) string {
+	switch color {
+	case sim.ColorOn:
+		return ansiWhite + "●" + ansiReset
+	case sim.ColorRed:
+		return ansiRed + "●" + ansiReset
+	case sim.ColorAmber:
+		return ansiAmber + "●" + ansiReset
+	case sim.ColorGreen:
+		return ansiGreen + "●" + ansiReset
+	}
+
+	return ansiDim + "○" + ansiReset
+}
@@ -9,7 +9,7 @@ import (
 )
 
 func init() {
-	flag.Var(&TC{"brightness scale", testBrightness}, "brightness", "Test brightness scale")
+	flag.Var(&TC{"brightness scale", testBrightness, false}, "brightness", "Test brightness scale")
 }
 
 func testBrightness(ctx *x52.Context) error {
@@ -0,0 +1,201 @@
+//go:build linux
+// +build linux
+
+package main
+
+// Linux evdev access for the input tests
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+const inputSupported = true
+
+// Event types and limits from linux/input-event-codes.h
+const (
+	evKey  = 0x01
+	evAbs  = 0x03
+	keyMax = 0x2ff
+	absMax = 0x3f
+)
+
+// USB identifiers of the supported joysticks, as reported in sysfs
+var inputVendor = "06a3"
+var inputProducts = []string{"0255", "075c", "0762"}
+
+// inputEvent matches struct input_event from linux/input.h
+type inputEvent struct {
+	Time  syscall.Timeval
+	Type  uint16
+	Code  uint16
+	Value int32
+}
+
+// time returns the time at which the kernel recorded the event
+func (ev *inputEvent) time() time.Time {
+	return time.Unix(ev.Time.Unix())
+}
+
+// absInfo matches struct input_absinfo from linux/input.h
+type absInfo struct {
+	Value      int32
+	Minimum    int32
+	Maximum    int32
+	Fuzz       int32
+	Flat       int32
+	Resolution int32
+}
+
+type inputDevice struct {
+	file *os.File
+	name string
+	path string
+}
+
+// openInputDevice finds the event device of the first supported joystick in
+// sysfs and opens it for reading
+func openInputDevice() (*inputDevice, error) {
+	nodes, err := filepath.Glob("/sys/class/input/event*")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range nodes {
+		vendor := readSysfs(node, "device/id/vendor")
+		product := readSysfs(node, "device/id/product")
+		if vendor != inputVendor || !supportedProduct(product) {
+			continue
+		}
+
+		path := filepath.Join("/dev/input", filepath.Base(node))
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return &inputDevice{
+			file: file,
+			name: readSysfs(node, "device/name"),
+			path: path,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no X52 input device found")
+}
+
+func readSysfs(node, attr string) string {
+	data, err := ioutil.ReadFile(filepath.Join(node, attr))
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
+func supportedProduct(product string) bool {
+	for _, p := range inputProducts {
+		if p == product {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (dev *inputDevice) Close() error {
+	return dev.file.Close()
+}
+
+// ioctl issues a read ioctl in the evdev ('E') namespace. The ioctl is made
+// through the raw connection rather than Fd, which would switch the device to
+// blocking mode and stop Close from interrupting the event reader.
+func (dev *inputDevice) ioctl(nr, size uintptr, data unsafe.Pointer) error {
+	const iocRead = 2
+	req := iocRead<<30 | size<<16 | 'E'<<8 | nr
+
+	conn, err := dev.file.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var errno syscall.Errno
+	err = conn.Control(func(fd uintptr) {
+		_, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(data))
+	})
+	if err != nil {
+		return err
+	}
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
+
+// codes returns the event codes supported for the given event type
+func (dev *inputDevice) codes(evType, max uintptr) ([]uint16, error) {
+	bits := make([]byte, max/8+1)
+	if err := dev.ioctl(0x20+evType, uintptr(len(bits)), unsafe.Pointer(&bits[0])); err != nil {
+		return nil, err
+	}
+
+	var codes []uint16
+	for code := uintptr(0); code <= max; code++ {
+		if bits[code/8]&(1<<(code%8)) != 0 {
+			codes = append(codes, uint16(code))
+		}
+	}
+
+	return codes, nil
+}
+
+// buttons returns the key codes reported by the device
+func (dev *inputDevice) buttons() ([]uint16, error) {
+	return dev.codes(evKey, keyMax)
+}
+
+// axes returns the absolute axes reported by the device, along with the
+// range and current value of each axis
+func (dev *inputDevice) axes() ([]uint16, []absInfo, error) {
+	codes, err := dev.codes(evAbs, absMax)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info := make([]absInfo, len(codes))
+	for i, code := range codes {
+		err = dev.ioctl(0x40+uintptr(code), unsafe.Sizeof(info[i]), unsafe.Pointer(&info[i]))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return codes, info, nil
+}
+
+// events starts reading events from the device. The returned channel is
+// closed when the device is closed or a read fails.
+func (dev *inputDevice) events() <-chan inputEvent {
+	ch := make(chan inputEvent, 64)
+
+	go func() {
+		defer close(ch)
+		for {
+			var ev inputEvent
+			if err := binary.Read(dev.file, binary.LittleEndian, &ev); err != nil {
+				return
+			}
+			ch <- ev
+		}
+	}()
+
+	return ch
+}
@@ -0,0 +1,60 @@
+//go:build !linux
+// +build !linux
+
+package main
+
+// Input tests rely on the Linux evdev interface, and are not available on
+// other platforms
+
+import (
+	"errors"
+	"time"
+)
+
+const inputSupported = false
+
+type inputEvent struct {
+	Type  uint16
+	Code  uint16
+	Value int32
+}
+
+func (ev *inputEvent) time() time.Time {
+	return time.Time{}
+}
+
+type absInfo struct {
+	Value   int32
+	Minimum int32
+	Maximum int32
+}
+
+const (
+	evKey = 0x01
+	evAbs = 0x03
+)
+
+type inputDevice struct {
+	name string
+	path string
+}
+
+func openInputDevice() (*inputDevice, error) {
+	return nil, errors.New("input tests are not supported on this platform")
+}
+
+func (dev *inputDevice) Close() error {
+	return nil
+}
+
+func (dev *inputDevice) buttons() ([]uint16, error) {
+	return nil, nil
+}
+
+func (dev *inputDevice) axes() ([]uint16, []absInfo, error) {
+	return nil, nil, nil
+}
+
+func (dev *inputDevice) events() <-chan inputEvent {
+	return nil
+}
@@ -0,0 +1,457 @@
+package main
+
+// Input tests
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"strings"
+	"time"
+
+	"nirenjan.org/saitek-x52/x52"
+)
+
+var inputReportFile string
+
+func init() {
+	flag.Var(&TC{"input", testInput, true}, "input", "Test buttons and axes (interactive, not run by default)")
+	flag.StringVar(&inputReportFile, "input-report", "", "Write the input test report as JSON to `file`")
+}
+
+const (
+	// Time allowed to let go of the controls before measuring axis noise
+	inputSettleTime = 2 * time.Second
+
+	// Time to leave the joystick untouched while measuring axis noise
+	inputRestTime = 5 * time.Second
+
+	// Time allowed to press every button
+	inputButtonTime = 60 * time.Second
+
+	// Time allowed to sweep each axis through its travel
+	inputAxisTime = 15 * time.Second
+
+	// Presses or releases closer together than this count as bounce
+	inputBounceWindow = 30 * time.Millisecond
+
+	// Number of slots the travel of each axis is divided into when looking
+	// for dead spots
+	inputAxisBins = 64
+)
+
+var axisNames = map[uint16]string{
+	0x00: "X",
+	0x01: "Y",
+	0x02: "Z",
+	0x03: "Rx",
+	0x04: "Ry",
+	0x05: "Rz",
+	0x06: "Throttle",
+	0x07: "Rudder",
+	0x10: "Hat X",
+	0x11: "Hat Y",
+	0x28: "Misc",
+}
+
+type inputReport struct {
+	Device  string         `json:"device"`
+	Path    string         `json:"path"`
+	Buttons []buttonReport `json:"buttons"`
+	Axes    []axisReport   `json:"axes"`
+}
+
+type buttonReport struct {
+	Name    string `json:"name"`
+	Code    uint16 `json:"code"`
+	Presses int    `json:"presses"`
+	Bounces int    `json:"bounces"`
+
+	lastPress   time.Time
+	lastRelease time.Time
+}
+
+type axisReport struct {
+	Name        string     `json:"name"`
+	Code        uint16     `json:"code"`
+	Minimum     int32      `json:"minimum"`
+	Maximum     int32      `json:"maximum"`
+	ObservedMin int32      `json:"observed_min"`
+	ObservedMax int32      `json:"observed_max"`
+	RestMean    float64    `json:"rest_mean"`
+	RestStdDev  float64    `json:"rest_stddev"`
+	RestRange   int32      `json:"rest_peak_to_peak"`
+	RestEvents  int        `json:"rest_events"`
+	DeadSpots   []axisSpan `json:"dead_spots"`
+
+	value int32
+	bins  []bool
+}
+
+type axisSpan struct {
+	From int32 `json:"from"`
+	To   int32 `json:"to"`
+}
+
+func testInput(ctx *x52.Context) error {
+	// The input tests need a user at the joystick, so they cannot be
+	// simulated
+	if mockTests || !inputSupported {
+		fmt.Println("Skipping input tests")
+		return nil
+	}
+
+	// The event device is often only readable by root or the input group,
+	// which should not stop the remaining tests
+	dev, err := openInputDevice()
+	if err != nil {
+		fmt.Println("Skipping input tests:", err)
+		return nil
+	}
+	events := dev.events()
+	defer func() {
+		dev.Close()
+		for range events {
+		}
+	}()
+
+	report := inputReport{Device: dev.name, Path: dev.path}
+
+	buttons, err := dev.buttons()
+	if err != nil {
+		return err
+	}
+	for i, code := range buttons {
+		report.Buttons = append(report.Buttons, buttonReport{
+			Name: fmt.Sprintf("Button %d", i+1),
+			Code: code,
+		})
+	}
+
+	codes, info, err := dev.axes()
+	if err != nil {
+		return err
+	}
+	for i, code := range codes {
+		name, ok := axisNames[code]
+		if !ok {
+			name = fmt.Sprintf("Axis %#02x", code)
+		}
+		report.Axes = append(report.Axes, axisReport{
+			Name:        name,
+			Code:        code,
+			Minimum:     info[i].Minimum,
+			Maximum:     info[i].Maximum,
+			ObservedMin: info[i].Value,
+			ObservedMax: info[i].Value,
+			value:       info[i].Value,
+		})
+	}
+
+	if err := testInputRest(dev, events, &report); err != nil {
+		return err
+	}
+
+	inputFuncs := []func(<-chan inputEvent, *inputReport) error{
+		testInputButtons,
+		testInputAxes,
+	}
+
+	for _, f := range inputFuncs {
+		if err := f(events, &report); err != nil {
+			return err
+		}
+	}
+
+	printInputReport(&report)
+
+	if inputReportFile != "" {
+		data, err := json.MarshalIndent(&report, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		return ioutil.WriteFile(inputReportFile, data, 0644)
+	}
+
+	return nil
+}
+
+// collectEvents passes incoming events to the handler until the handler
+// returns true or the timeout expires
+func collectEvents(events <-chan inputEvent, timeout time.Duration, handler func(*inputEvent) bool) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return errors.New("input device closed")
+			}
+			if handler(&ev) {
+				return nil
+			}
+
+		case <-timer.C:
+			return nil
+		}
+	}
+}
+
+func testInputRest(dev *inputDevice, events <-chan inputEvent, report *inputReport) error {
+	fmt.Println("Release all controls and leave the joystick untouched")
+
+	// Discard the events from letting go of the controls, so that they are
+	// not counted as noise, and start from the values the axes settled at
+	err := collectEvents(events, inputSettleTime, func(*inputEvent) bool {
+		return false
+	})
+	if err != nil {
+		return err
+	}
+
+	_, info, err := dev.axes()
+	if err != nil {
+		return err
+	}
+	for i := range report.Axes {
+		report.Axes[i].value = info[i].Value
+	}
+
+	samples := make([][]int32, len(report.Axes))
+	for i := range report.Axes {
+		samples[i] = []int32{report.Axes[i].value}
+	}
+
+	handler := func(ev *inputEvent) bool {
+		if ev.Type != evAbs {
+			return false
+		}
+
+		for i := range report.Axes {
+			axis := &report.Axes[i]
+			if axis.Code == ev.Code {
+				axis.value = ev.Value
+				axis.RestEvents++
+				samples[i] = append(samples[i], ev.Value)
+			}
+		}
+
+		return false
+	}
+
+	seconds := int(inputRestTime / time.Second)
+	bar := progressBar("Axis noise", seconds)
+	for i := 0; i < seconds; i++ {
+		if err := collectEvents(events, time.Second, handler); err != nil {
+			bar.Clear()
+			return err
+		}
+		bar.Add(1)
+	}
+
+	for i := range report.Axes {
+		axis := &report.Axes[i]
+		min, max := samples[i][0], samples[i][0]
+		var sum float64
+		for _, v := range samples[i] {
+			sum += float64(v)
+			if v < min {
+				min = v
+			}
+			if v > max {
+				max = v
+			}
+		}
+
+		axis.RestMean = sum / float64(len(samples[i]))
+		axis.RestRange = max - min
+
+		var variance float64
+		for _, v := range samples[i] {
+			variance += math.Pow(float64(v)-axis.RestMean, 2)
+		}
+		axis.RestStdDev = math.Sqrt(variance / float64(len(samples[i])))
+	}
+
+	return nil
+}
+
+func testInputButtons(events <-chan inputEvent, report *inputReport) error {
+	fmt.Println("Press and release every button, including hat directions and mode selectors")
+
+	bar := progressBar("Buttons", len(report.Buttons))
+	remaining := len(report.Buttons)
+
+	handler := func(ev *inputEvent) bool {
+		if ev.Type != evKey {
+			return false
+		}
+
+		for i := range report.Buttons {
+			button := &report.Buttons[i]
+			if button.Code != ev.Code {
+				continue
+			}
+
+			t := ev.time()
+			switch ev.Value {
+			case 1: // Pressed
+				if !button.lastRelease.IsZero() && t.Sub(button.lastRelease) < inputBounceWindow {
+					button.Bounces++
+				}
+				button.lastPress = t
+				button.Presses++
+				if button.Presses == 1 {
+					remaining--
+					bar.Add(1)
+				}
+
+			case 0: // Released
+				if !button.lastPress.IsZero() && t.Sub(button.lastPress) < inputBounceWindow {
+					button.Bounces++
+				}
+				button.lastRelease = t
+			}
+		}
+
+		return remaining == 0
+	}
+
+	err := collectEvents(events, inputButtonTime, handler)
+	if remaining != 0 {
+		bar.Finish()
+	}
+
+	return err
+}
+
+func testInputAxes(events <-chan inputEvent, report *inputReport) error {
+	for i := range report.Axes {
+		axis := &report.Axes[i]
+
+		bins := int64(axis.Maximum) - int64(axis.Minimum) + 1
+		if bins > inputAxisBins {
+			bins = inputAxisBins
+		}
+		if bins <= 0 {
+			continue
+		}
+		axis.bins = make([]bool, bins)
+
+		fmt.Printf("Slowly move %s through its full range of travel\n", axis.Name)
+		bar := progressBar(fmt.Sprintf("%s travel", axis.Name), len(axis.bins))
+		remaining := len(axis.bins)
+
+		visit := func(value int32) {
+			if value < axis.ObservedMin {
+				axis.ObservedMin = value
+			}
+			if value > axis.ObservedMax {
+				axis.ObservedMax = value
+			}
+
+			bin := axis.bin(value)
+			if !axis.bins[bin] {
+				axis.bins[bin] = true
+				remaining--
+				bar.Add(1)
+			}
+		}
+
+		visit(axis.value)
+		handler := func(ev *inputEvent) bool {
+			if ev.Type == evAbs && ev.Code == axis.Code {
+				axis.value = ev.Value
+				visit(ev.Value)
+			}
+
+			return remaining == 0
+		}
+
+		err := collectEvents(events, inputAxisTime, handler)
+		if remaining != 0 {
+			bar.Finish()
+		}
+		if err != nil {
+			return err
+		}
+
+		axis.findDeadSpots()
+	}
+
+	return nil
+}
+
+// bin returns the slot in the axis travel that the value falls into
+func (axis *axisReport) bin(value int32) int {
+	if value < axis.Minimum {
+		value = axis.Minimum
+	} else if value > axis.Maximum {
+		value = axis.Maximum
+	}
+
+	span := int64(axis.Maximum) - int64(axis.Minimum) + 1
+	return int((int64(value) - int64(axis.Minimum)) * int64(len(axis.bins)) / span)
+}
+
+// findDeadSpots records the ranges of travel between the observed extremes
+// that never reported a value
+func (axis *axisReport) findDeadSpots() {
+	span := int64(axis.Maximum) - int64(axis.Minimum) + 1
+	binValue := func(bin int) int32 {
+		return int32(int64(axis.Minimum) + (int64(bin)*span+int64(len(axis.bins))-1)/int64(len(axis.bins)))
+	}
+
+	start := -1
+	for bin := axis.bin(axis.ObservedMin); bin <= axis.bin(axis.ObservedMax); bin++ {
+		if !axis.bins[bin] {
+			if start < 0 {
+				start = bin
+			}
+			continue
+		}
+
+		if start >= 0 {
+			axis.DeadSpots = append(axis.DeadSpots, axisSpan{
+				From: binValue(start),
+				To:   binValue(bin) - 1,
+			})
+			start = -1
+		}
+	}
+}
+
+func printInputReport(report *inputReport) {
+	var missed, bouncy []string
+	for _, button := range report.Buttons {
+		if button.Presses == 0 {
+			missed = append(missed, button.Name)
+		}
+		if button.Bounces != 0 {
+			bouncy = append(bouncy, fmt.Sprintf("%s (%d)", button.Name, button.Bounces))
+		}
+	}
+
+	fmt.Printf("\nInput test report for %s\n", report.Device)
+	fmt.Printf("Buttons pressed: %d of %d\n", len(report.Buttons)-len(missed), len(report.Buttons))
+	if len(missed) != 0 {
+		fmt.Println("Missed buttons:", strings.Join(missed, ", "))
+	}
+	if len(bouncy) != 0 {
+		fmt.Println("Bouncing buttons:", strings.Join(bouncy, ", "))
+	}
+
+	for _, axis := range report.Axes {
+		fmt.Printf("%-8s range %d..%d of %d..%d, noise %.2f (peak-to-peak %d, %d events)\n",
+			axis.Name, axis.ObservedMin, axis.ObservedMax, axis.Minimum, axis.Maximum,
+			axis.RestStdDev, axis.RestRange, axis.RestEvents)
+		for _, spot := range axis.DeadSpots {
+			fmt.Printf("%-8s dead spot %d..%d\n", "", spot.From, spot.To)
+		}
+	}
+}
@@ -11,7 +11,7 @@ import (
 )
 
 func init() {
-	flag.Var(&TC{"LED state", testLED}, "led", "Test LED states")
+	flag.Var(&TC{"LED state", testLED, false}, "led", "Test LED states")
 }
 
 func testLED(ctx *x52.Context) error {
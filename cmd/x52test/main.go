@@ -18,6 +18,9 @@ var selectTests bool
 type TC struct {
 	name    string
 	handler func(*x52.Context) error
+
+	// optIn tests are only run when they are selected on the command line
+	optIn bool
 }
 
 func (tc *TC) IsBoolFlag() bool {
@@ -47,7 +50,7 @@ func main() {
 	flag.Usage = func() {
 		fmt.Fprintf(flag.CommandLine.Output(), "Usage of %s:\n", os.Args[0])
 		flag.PrintDefaults()
-		fmt.Fprintln(flag.CommandLine.Output(), "\nAll tests are run if no flags are specified")
+		fmt.Fprintln(flag.CommandLine.Output(), "\nAll tests except -input are run if no flags are specified")
 	}
 
 	flag.BoolVar(&mockTests, "mock", false, "Don't actually run the tests, just simulate the output")
@@ -75,7 +78,7 @@ func main() {
 			return
 		}
 		value, ok := f.Value.(*TC)
-		if !ok {
+		if !ok || (value.optIn && !selectTests) {
 			return
 		}
 		fmt.Printf("Running %s tests\n", value.name)
@@ -11,7 +11,7 @@ import (
 )
 
 func init() {
-	flag.Var(&TC{"MFD", testMFD}, "mfd", "Test multifunction display")
+	flag.Var(&TC{"MFD", testMFD, false}, "mfd", "Test multifunction display")
 }
 
 func testMFD(ctx *x52.Context) error {
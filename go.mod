@@ -2,4 +2,7 @@ module nirenjan.org/saitek-x52
 
 go 1.14
 
-require github.com/google/gousb v1.1.0
+require (
+	github.com/google/gousb v1.1.0
+	golang.org/x/text v0.3.6
+)
@@ -0,0 +1,137 @@
+package x52
+
+// AxisZone is a range of positions of an axis that acts as a virtual button,
+// such as the idle cutoff or afterburner detent of the throttle. The zone is
+// entered when the axis moves within Min and Max inclusive, and is only left
+// once the axis moves more than Hysteresis beyond either end, so that noise
+// near the edge of the zone does not toggle it repeatedly.
+type AxisZone struct {
+	Name       string
+	Min, Max   int
+	Hysteresis int
+
+	// LED is optionally set to LedState while the zone is active, and
+	// turned off when it is left. A zero LED disables this. Zones may share
+	// an LED, which is then set by the last added zone that is active, and
+	// only turned off once none of them are active.
+	LED      LED
+	LedState LedState
+}
+
+// ZoneEvent reports that a zone was entered or left
+type ZoneEvent struct {
+	Zone    string
+	Entered bool
+}
+
+// AxisZones tracks the zones of a single axis. The library does not read the
+// joystick input, so the application passes each new axis value to Update,
+// which returns the zones that were entered or left, and sets the LEDs of the
+// zones on the context. The application must still call Update on the context
+// to write the LEDs to the joystick.
+type AxisZones struct {
+	ctx   *Context
+	zones []axisZoneState
+}
+
+// axisZoneState is a zone and whether it is active
+type axisZoneState struct {
+	zone   AxisZone
+	active bool
+}
+
+// NewAxisZones returns an empty set of zones. The context is used to set the
+// LEDs of the zones, and may be nil if no zone has an LED.
+func NewAxisZones(ctx *Context) *AxisZones {
+	return &AxisZones{ctx: ctx}
+}
+
+// Add adds the zone, which is initially inactive. Zones may overlap, in which
+// case all of them are active together.
+func (az *AxisZones) Add(zone AxisZone) error {
+	if zone.Min > zone.Max {
+		return errInvalidParam("zone minimum is greater than maximum")
+	}
+	if zone.Hysteresis < 0 {
+		return errInvalidParam("negative zone hysteresis")
+	}
+	for _, zs := range az.zones {
+		if zs.zone.Name == zone.Name {
+			return errInvalidParam("duplicate zone name")
+		}
+	}
+
+	if zone.LED != 0 {
+		if az.ctx == nil {
+			return errInvalidParam("zone LED requires a context")
+		}
+
+		var err error
+		if zone.LedState, err = boundLedState(zone.LED, zone.LedState); err != nil {
+			return err
+		}
+	}
+
+	az.zones = append(az.zones, axisZoneState{zone: zone})
+	return nil
+}
+
+// Update moves the axis to the given value, and returns the zones that were
+// left, followed by the zones that were entered. The zones are updated even if
+// setting an LED fails, so the events are returned along with the error.
+func (az *AxisZones) Update(value int) ([]ZoneEvent, error) {
+	var left, entered []ZoneEvent
+	var changed []LED
+
+	for i := range az.zones {
+		zs := &az.zones[i]
+		z := zs.zone
+
+		if !zs.active && value >= z.Min && value <= z.Max {
+			zs.active = true
+			entered = append(entered, ZoneEvent{z.Name, true})
+		} else if zs.active && (value < z.Min-z.Hysteresis || value > z.Max+z.Hysteresis) {
+			zs.active = false
+			left = append(left, ZoneEvent{z.Name, false})
+		} else {
+			continue
+		}
+
+		if z.LED != 0 {
+			changed = append(changed, z.LED)
+		}
+	}
+
+	events := append(left, entered...)
+	for _, led := range changed {
+		if err := az.ctx.SetLed(led, az.ledState(led)); err != nil {
+			return events, err
+		}
+	}
+
+	return events, nil
+}
+
+// Active returns true if the named zone is active
+func (az *AxisZones) Active(name string) bool {
+	for _, zs := range az.zones {
+		if zs.zone.Name == name {
+			return zs.active
+		}
+	}
+
+	return false
+}
+
+// ledState returns the state of the LED from the last added active zone that
+// uses it, or LedOff if there is none
+func (az *AxisZones) ledState(led LED) LedState {
+	state := LedOff
+	for _, zs := range az.zones {
+		if zs.active && zs.zone.LED == led {
+			state = zs.zone.LedState
+		}
+	}
+
+	return state
+}
@@ -0,0 +1,106 @@
+package x52
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAxisZones(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	bitSet(&ctx.featureFlags, FeatureLED)
+
+	az := NewAxisZones(ctx)
+	if err := az.Add(AxisZone{Name: "idle", Min: 0, Max: 10, Hysteresis: 5}); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	if err := az.Add(AxisZone{Name: "ab", Min: 240, Max: 255, Hysteresis: 5,
+		LED: LedThrottle, LedState: LedRed}); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	for _, tc := range []struct {
+		value    int
+		expected []ZoneEvent
+		throttle LedState
+	}{
+		{5, []ZoneEvent{{"idle", true}}, LedOff},
+		{14, nil, LedOff},
+		{16, []ZoneEvent{{"idle", false}}, LedOff},
+		{10, []ZoneEvent{{"idle", true}}, LedOff},
+		{245, []ZoneEvent{{"idle", false}, {"ab", true}}, LedOn},
+		{236, nil, LedOn},
+		{234, []ZoneEvent{{"ab", false}}, LedOff},
+	} {
+		events, err := az.Update(tc.value)
+		if err != nil {
+			t.Fatal("Unexpected error", err)
+		}
+		if !reflect.DeepEqual(events, tc.expected) {
+			t.Errorf("Value %d: expected %v, got %v", tc.value, tc.expected, events)
+		}
+		if got := ledState(ctx, LedThrottle); got != tc.throttle {
+			t.Errorf("Value %d: expected throttle LED %v, got %v", tc.value, tc.throttle, got)
+		}
+	}
+
+	if az.Active("idle") || az.Active("ab") || az.Active("missing") {
+		t.Error("Expected no active zones")
+	}
+
+	for _, z := range []AxisZone{
+		{Name: "bad", Min: 10, Max: 0},
+		{Name: "bad", Hysteresis: -1},
+		{Name: "idle"},
+		{Name: "bad", LED: LedA, LedState: LedOn},
+	} {
+		if err := az.Add(z); err == nil {
+			t.Errorf("Expected error adding %v", z)
+		}
+	}
+
+	if err := NewAxisZones(nil).Add(AxisZone{Name: "led", LED: LedA, LedState: LedRed}); err == nil {
+		t.Error("Expected error adding an LED zone without a context")
+	}
+}
+
+func TestAxisZonesSharedLed(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	bitSet(&ctx.featureFlags, FeatureLED)
+
+	az := NewAxisZones(ctx)
+	az.Add(AxisZone{Name: "low", Min: 0, Max: 100, LED: LedA, LedState: LedGreen})
+	az.Add(AxisZone{Name: "warn", Min: 50, Max: 150, LED: LedA, LedState: LedAmber})
+
+	for _, tc := range []struct {
+		value int
+		led   LedState
+	}{
+		{20, LedGreen},
+		{80, LedAmber},
+		{120, LedAmber},
+		{20, LedGreen},
+		{200, LedOff},
+	} {
+		if _, err := az.Update(tc.value); err != nil {
+			t.Fatal("Unexpected error", err)
+		}
+		if got := ledState(ctx, LedA); got != tc.led {
+			t.Errorf("Value %d: expected LED %v, got %v", tc.value, tc.led, got)
+		}
+	}
+
+	// The events are not lost if the LED cannot be set
+	bitClear(&ctx.featureFlags, FeatureLED)
+	events, err := az.Update(20)
+	if err == nil {
+		t.Error("Expected error setting the LED")
+	}
+	if exp := []ZoneEvent{{"low", true}}; !reflect.DeepEqual(events, exp) {
+		t.Errorf("Expected %v, got %v", exp, events)
+	}
+	if !az.Active("low") {
+		t.Error("Expected low zone to be active")
+	}
+}
@@ -52,7 +52,7 @@ func (ctx *Context) Update() error {
 
 		case updatePOVBlink:
 			value = 0x50 // Blink OFF
-			if bitTest(ctx.ledMask, updateShift) {
+			if bitTest(ctx.ledMask, updatePOVBlink) {
 				// Blink ON
 				value |= 1
 			}
@@ -41,7 +41,16 @@ func devSupported(desc *gousb.DeviceDesc) bool {
 // joystick is plugged in and the function succeeds, it returns true, otherwise
 // it returns false. If multiple supported devices are plugged in, then it will
 // pick one of the supported devices in an unspecified manner.
+//
+// If the X52_SIMULATOR environment variable is set, then Connect will instead
+// connect to the simulator listening on the Unix domain socket at that path,
+// or at the TCP address if it is in the form tcp:host:port. This is also used
+// to connect to a joystick served by another machine.
 func (ctx *Context) Connect() bool {
+	if path := simulatorPath(); path != "" {
+		return ctx.connectSimulator(path)
+	}
+
 	devlist, err := ctx.usbContext.OpenDevices(devSupported)
 
 	if err != nil {
@@ -0,0 +1,150 @@
+package x52
+
+// LedBinding describes how the LED of a lit button shows the state of that
+// button. The LED identifies the button, since each lit button has its own
+// LED. A momentary binding shows On while the button is pressed and Off
+// otherwise. A toggle binding flips between Off and On each time the button is
+// pressed, and can optionally show Held while the button is pressed.
+//
+// LedFire and LedThrottle only support LedOn and LedOff, so any color state
+// bound to them is shown as LedOn. The remaining LEDs do not support LedOn.
+type LedBinding struct {
+	LED    LED
+	Off    LedState
+	On     LedState
+	Held   LedState
+	Toggle bool
+
+	// ShowHeld shows the Held state while the button is pressed, instead
+	// of the Off or On state
+	ShowHeld bool
+}
+
+// LedBindings evaluates LED bindings from the state of the buttons. The
+// library does not read the joystick input, so the application reports button
+// presses with SetPressed, and LedBindings makes the corresponding SetLed calls
+// on the context. The application must still call Update on the context to
+// write the LEDs to the joystick.
+type LedBindings struct {
+	ctx      *Context
+	bindings map[LED]*ledBindingState
+}
+
+// ledBindingState is a binding and the current state of its button
+type ledBindingState struct {
+	binding LedBinding
+	pressed bool
+	toggled bool
+	state   LedState
+}
+
+// NewLedBindings returns an empty set of bindings that sets the LEDs of the
+// given context
+func NewLedBindings(ctx *Context) *LedBindings {
+	return &LedBindings{
+		ctx:      ctx,
+		bindings: make(map[LED]*ledBindingState),
+	}
+}
+
+// Bind adds the binding, replacing any existing binding for the same LED, and
+// sets the LED to the Off state of the binding. The button is initially
+// released and toggled off.
+func (lb *LedBindings) Bind(binding LedBinding) error {
+	for _, state := range []*LedState{&binding.Off, &binding.On, &binding.Held} {
+		var err error
+		if *state, err = boundLedState(binding.LED, *state); err != nil {
+			return err
+		}
+	}
+
+	bs := &ledBindingState{binding: binding}
+	lb.bindings[binding.LED] = bs
+	return lb.apply(bs, true)
+}
+
+// Unbind removes the binding for the LED, leaving the LED in its current state
+func (lb *LedBindings) Unbind(led LED) {
+	delete(lb.bindings, led)
+}
+
+// SetPressed reports whether the button of the LED is pressed, and updates
+// the LED if its state has changed. Buttons without a binding are ignored.
+func (lb *LedBindings) SetPressed(led LED, pressed bool) error {
+	bs, ok := lb.bindings[led]
+	if !ok || bs.pressed == pressed {
+		return nil
+	}
+
+	bs.pressed = pressed
+	if pressed && bs.binding.Toggle {
+		bs.toggled = !bs.toggled
+	}
+	return lb.apply(bs, false)
+}
+
+// SetToggled sets the toggle state of the button of the LED, such as when the
+// application restores a saved state, and updates the LED if its state has
+// changed
+func (lb *LedBindings) SetToggled(led LED, toggled bool) error {
+	bs, ok := lb.bindings[led]
+	if !ok {
+		return errInvalidParam("LED is not bound")
+	}
+
+	bs.toggled = toggled
+	return lb.apply(bs, false)
+}
+
+// Toggled returns the toggle state of the button of the LED
+func (lb *LedBindings) Toggled(led LED) bool {
+	bs, ok := lb.bindings[led]
+	return ok && bs.toggled
+}
+
+// apply sets the LED to the state for the current button state, if it has
+// changed or force is set
+func (lb *LedBindings) apply(bs *ledBindingState, force bool) error {
+	b := bs.binding
+
+	var state LedState
+	switch {
+	case bs.pressed && b.ShowHeld:
+		state = b.Held
+	case b.Toggle && bs.toggled, !b.Toggle && bs.pressed:
+		state = b.On
+	default:
+		state = b.Off
+	}
+
+	if state == bs.state && !force {
+		return nil
+	}
+
+	if err := lb.ctx.SetLed(b.LED, state); err != nil {
+		return err
+	}
+	bs.state = state
+	return nil
+}
+
+// boundLedState checks that the state can be bound to the LED. Any state
+// other than LedOff is shown as LedOn on the on/off LEDs, and the color LEDs
+// do not support LedOn.
+func boundLedState(led LED, state LedState) (LedState, error) {
+	switch led {
+	case LedFire, LedThrottle:
+		if state == LedOff {
+			return LedOff, nil
+		}
+		return LedOn, nil
+
+	case LedA, LedB, LedD, LedE, LedT1, LedT2, LedT3, LedPOV, LedClutch:
+		if state == LedOn || state > LedGreen {
+			return state, errInvalidParam("invalid state for color LED")
+		}
+		return state, nil
+	}
+
+	return state, errInvalidParam("invalid LED identifier")
+}
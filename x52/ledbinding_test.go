@@ -0,0 +1,99 @@
+package x52
+
+import (
+	"testing"
+)
+
+// ledState returns the state of the LED from the LED mask of the context
+func ledState(ctx *Context, led LED) LedState {
+	id := uint32(led)
+	if led == LedFire || led == LedThrottle {
+		if bitTest(ctx.ledMask, id) {
+			return LedOn
+		}
+		return LedOff
+	}
+
+	red, green := bitTest(ctx.ledMask, id), bitTest(ctx.ledMask, id+1)
+	switch {
+	case red && green:
+		return LedAmber
+	case red:
+		return LedRed
+	case green:
+		return LedGreen
+	}
+	return LedOff
+}
+
+func TestLedBindings(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	bitSet(&ctx.featureFlags, FeatureLED)
+
+	lb := NewLedBindings(ctx)
+
+	check := func(led LED, expected LedState) {
+		t.Helper()
+		if got := ledState(ctx, led); got != expected {
+			t.Errorf("%v: expected %v, got %v", led, expected, got)
+		}
+	}
+
+	// A green when toggled on, red when off, amber while held
+	if err := lb.Bind(LedBinding{LED: LedA, Off: LedRed, On: LedGreen,
+		Held: LedAmber, ShowHeld: true, Toggle: true}); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	check(LedA, LedRed)
+
+	lb.SetPressed(LedA, true)
+	check(LedA, LedAmber)
+	lb.SetPressed(LedA, false)
+	check(LedA, LedGreen)
+	if !lb.Toggled(LedA) {
+		t.Error("Expected A to be toggled on")
+	}
+
+	lb.SetPressed(LedA, true)
+	lb.SetPressed(LedA, false)
+	check(LedA, LedRed)
+
+	lb.SetToggled(LedA, true)
+	check(LedA, LedGreen)
+
+	// Color states on the fire LED are shown as on
+	if err := lb.Bind(LedBinding{LED: LedFire, Off: LedOff, On: LedRed}); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	check(LedFire, LedOff)
+	lb.SetPressed(LedFire, true)
+	check(LedFire, LedOn)
+	lb.SetPressed(LedFire, false)
+	check(LedFire, LedOff)
+
+	// The LED is only set when its state changes
+	ctx.updateMask = 0
+	lb.SetPressed(LedFire, false)
+	lb.SetPressed(LedB, true)
+	if ctx.updateMask != 0 {
+		t.Errorf("Unexpected update mask %08x", ctx.updateMask)
+	}
+
+	// Unbound LEDs are left alone
+	lb.Unbind(LedA)
+	lb.SetPressed(LedA, true)
+	check(LedA, LedGreen)
+	if err := lb.SetToggled(LedA, false); err == nil {
+		t.Error("Expected error toggling an unbound LED")
+	}
+
+	for _, b := range []LedBinding{
+		{LED: LedB, On: LedOn},
+		{LED: LED(21)},
+	} {
+		if err := lb.Bind(b); err == nil {
+			t.Errorf("Expected error binding %v", b)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package x52
+
+import (
+	"sync"
+	"time"
+)
+
+// MacroStep is a single step of a macro, which sets an LED, the text of an MFD
+// line, or both
+type MacroStep struct {
+	// Delay is the time to wait after the previous step, or after the
+	// macro starts for the first step
+	Delay time.Duration
+
+	// LED is set to LedState, unless it is zero
+	LED      LED
+	LedState LedState
+
+	// Text is written to the MFD line, unless it is nil
+	Line uint8
+	Text []byte
+}
+
+// Macro is a timed sequence of LED and MFD changes that is started by a
+// button. Each press of the button starts the next sequence in Steps, wrapping
+// around from the last sequence to the first, so that a macro with a single
+// sequence runs it on every press, and one with several sequences cycles
+// through them.
+type Macro struct {
+	Steps [][]MacroStep
+
+	// CancelOnRelease cancels the running sequence when the button is
+	// released
+	CancelOnRelease bool
+
+	// Toggle cancels the running sequence when the button is pressed again,
+	// instead of starting the next sequence
+	Toggle bool
+
+	// Cancel is run instead of the rest of the sequence when it is
+	// cancelled, such as to turn off an LED that the sequence turned on
+	Cancel []MacroStep
+}
+
+// Macros runs the macros bound to the buttons of the joystick. The library does
+// not read the joystick input, so the application reports button presses with
+// Press and Release, and calls Tick periodically to run the steps that are
+// due. Press and Release only record the button state, so they may be called
+// from the input handling of the application while Tick runs on another
+// goroutine. The application must still call Update on the context to write
+// the changes to the joystick.
+type Macros struct {
+	ctx *Context
+
+	mu     sync.Mutex
+	macros []*macroState
+}
+
+// macroState is a bound macro and the state of its sequence
+type macroState struct {
+	button  int
+	macro   Macro
+	pressed bool
+	next    int
+
+	// steps is the running sequence, if any, and step is the index of the
+	// next step to run. The sequence starts on the next call to Tick if
+	// pending is set, otherwise the steps are timed from last. The cancel
+	// steps of the macro cannot themselves be cancelled.
+	steps      []MacroStep
+	step       int
+	pending    bool
+	last       time.Time
+	cancelling bool
+}
+
+// NewMacros returns an empty set of macros that sets the LEDs and MFD text of
+// the given context
+func NewMacros(ctx *Context) *Macros {
+	return &Macros{ctx: ctx}
+}
+
+// Bind binds the macro to the button, replacing any existing macro for the
+// button. Buttons are identified by any number chosen by the application,
+// such as the button number reported by the input driver.
+func (m *Macros) Bind(button int, macro Macro) error {
+	if len(macro.Steps) == 0 {
+		return errInvalidParam("macro has no steps")
+	}
+
+	// Copy the steps, so that the caller cannot modify them while bound
+	steps := make([][]MacroStep, len(macro.Steps))
+	for i, seq := range macro.Steps {
+		var err error
+		if steps[i], err = macroSteps(seq); err != nil {
+			return err
+		}
+	}
+	macro.Steps = steps
+
+	var err error
+	if macro.Cancel, err = macroSteps(macro.Cancel); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ms := &macroState{button: button, macro: macro}
+	if i := m.find(button); i >= 0 {
+		m.macros[i] = ms
+	} else {
+		m.macros = append(m.macros, ms)
+	}
+	return nil
+}
+
+// Unbind removes the macro for the button, stopping it if it is running
+func (m *Macros) Unbind(button int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if i := m.find(button); i >= 0 {
+		m.macros = append(m.macros[:i], m.macros[i+1:]...)
+	}
+}
+
+// Press reports that the button was pressed. This starts the next sequence of
+// its macro on the next call to Tick, or cancels the running sequence of a
+// toggle macro. Buttons without a macro, and repeated presses without a
+// release, are ignored.
+func (m *Macros) Press(button int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := m.find(button)
+	if i < 0 || m.macros[i].pressed {
+		return
+	}
+
+	ms := m.macros[i]
+	ms.pressed = true
+	if ms.macro.Toggle && ms.cancellable() {
+		ms.cancel()
+		return
+	}
+
+	ms.start(ms.macro.Steps[ms.next], false)
+	ms.next = (ms.next + 1) % len(ms.macro.Steps)
+}
+
+// Release reports that the button was released, which cancels the running
+// sequence if the macro is cancelled on release
+func (m *Macros) Release(button int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := m.find(button)
+	if i < 0 || !m.macros[i].pressed {
+		return
+	}
+
+	ms := m.macros[i]
+	ms.pressed = false
+	if ms.macro.CancelOnRelease && ms.cancellable() {
+		ms.cancel()
+	}
+}
+
+// Running returns true if a sequence of the macro for the button, or its
+// cancel steps, are running
+func (m *Macros) Running(button int) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := m.find(button)
+	return i >= 0 && m.macros[i].running()
+}
+
+// Tick starts the sequences of the buttons that were pressed since the
+// previous call, and runs every step that is due at the given time. If a step
+// fails, then the error is returned, and the remaining steps are run on the
+// following calls.
+func (m *Macros) Tick(now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, ms := range m.macros {
+		if ms.pending {
+			ms.last = now
+			ms.pending = false
+		}
+
+		for ms.running() {
+			step := ms.steps[ms.step]
+			due := ms.last.Add(step.Delay)
+			if now.Before(due) {
+				break
+			}
+
+			ms.last = due
+			ms.step++
+			if err := m.run(step); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// run applies the step to the context
+func (m *Macros) run(step MacroStep) error {
+	if step.LED != 0 {
+		if err := m.ctx.SetLed(step.LED, step.LedState); err != nil {
+			return err
+		}
+	}
+
+	if step.Text != nil {
+		return m.ctx.SetMFDText(step.Line, step.Text)
+	}
+
+	return nil
+}
+
+// find returns the index of the macro for the button, or -1 if there is none
+func (m *Macros) find(button int) int {
+	for i, ms := range m.macros {
+		if ms.button == button {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// running returns true if the sequence has steps left to run
+func (ms *macroState) running() bool {
+	return ms.step < len(ms.steps)
+}
+
+// cancellable returns true if a sequence other than the cancel steps is
+// running
+func (ms *macroState) cancellable() bool {
+	return ms.running() && !ms.cancelling
+}
+
+// start replaces the running sequence, which starts on the next call to Tick
+func (ms *macroState) start(steps []MacroStep, cancelling bool) {
+	ms.steps = steps
+	ms.step = 0
+	ms.pending = true
+	ms.cancelling = cancelling
+}
+
+// cancel replaces the running sequence with the cancel steps of the macro
+func (ms *macroState) cancel() {
+	ms.start(ms.macro.Cancel, true)
+}
+
+// macroSteps checks the steps of a macro, and returns a copy of them with the
+// LED states adjusted for the LEDs
+func macroSteps(steps []MacroStep) ([]MacroStep, error) {
+	out := make([]MacroStep, len(steps))
+	for i, step := range steps {
+		if step.Delay < 0 {
+			return nil, errInvalidParam("negative macro step delay")
+		}
+
+		if step.LED != 0 {
+			var err error
+			if step.LedState, err = boundLedState(step.LED, step.LedState); err != nil {
+				return nil, err
+			}
+		}
+
+		if step.Text != nil {
+			if step.Line >= mfdLines {
+				return nil, errInvalidParam("line number out of range")
+			}
+			step.Text = append([]byte(nil), step.Text...)
+		}
+
+		out[i] = step
+	}
+
+	return out, nil
+}
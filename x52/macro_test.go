@@ -0,0 +1,126 @@
+package x52
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMacros(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	bitSet(&ctx.featureFlags, FeatureLED)
+
+	m := NewMacros(ctx)
+	base := time.Unix(1600000000, 0)
+	at := func(ms int) time.Time {
+		return base.Add(time.Duration(ms) * time.Millisecond)
+	}
+
+	check := func(led LED, expected LedState) {
+		t.Helper()
+		if got := ledState(ctx, led); got != expected {
+			t.Errorf("%v: expected %v, got %v", led, expected, got)
+		}
+	}
+
+	// Timed sequence
+	err := m.Bind(1, Macro{Steps: [][]MacroStep{{
+		{LED: LedA, LedState: LedGreen},
+		{Delay: 100 * time.Millisecond, Line: 1, Text: []byte("GEAR DOWN")},
+		{Delay: 200 * time.Millisecond, LED: LedA, LedState: LedOff},
+	}}})
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	m.Press(1)
+	m.Release(1)
+	m.Tick(at(0))
+	check(LedA, LedGreen)
+	if ctx.mfdLine[1] != nil {
+		t.Errorf("Unexpected line %q", ctx.mfdLine[1])
+	}
+	m.Tick(at(99))
+	if ctx.mfdLine[1] != nil {
+		t.Errorf("Unexpected line %q", ctx.mfdLine[1])
+	}
+	m.Tick(at(100))
+	if string(ctx.mfdLine[1]) != "GEAR DOWN" {
+		t.Errorf("Unexpected line %q", ctx.mfdLine[1])
+	}
+	if !m.Running(1) {
+		t.Error("Expected macro to be running")
+	}
+	m.Tick(at(300))
+	check(LedA, LedOff)
+	if m.Running(1) {
+		t.Error("Expected macro to be finished")
+	}
+
+	// Cancel on release runs the cancel steps instead of the rest
+	m.Bind(2, Macro{
+		Steps: [][]MacroStep{{
+			{LED: LedB, LedState: LedRed},
+			{Delay: time.Second, LED: LedB, LedState: LedGreen},
+		}},
+		CancelOnRelease: true,
+		Cancel:          []MacroStep{{LED: LedB, LedState: LedOff}},
+	})
+	m.Press(2)
+	m.Tick(at(1000))
+	check(LedB, LedRed)
+	m.Release(2)
+	m.Tick(at(1500))
+	check(LedB, LedOff)
+	m.Tick(at(3000))
+	check(LedB, LedOff)
+
+	// Toggle cancels on the next press
+	m.Bind(3, Macro{
+		Steps:  [][]MacroStep{{{LED: LedT1, LedState: LedAmber}, {Delay: time.Hour}}},
+		Toggle: true,
+		Cancel: []MacroStep{{LED: LedT1, LedState: LedGreen}},
+	})
+	m.Press(3)
+	m.Release(3)
+	m.Tick(at(4000))
+	check(LedT1, LedAmber)
+	m.Press(3)
+	m.Release(3)
+	m.Tick(at(5000))
+	check(LedT1, LedGreen)
+	if m.Running(3) {
+		t.Error("Expected toggle macro to be cancelled")
+	}
+
+	// Repeated presses cycle through the sequences
+	m.Bind(4, Macro{Steps: [][]MacroStep{
+		{{LED: LedFire, LedState: LedRed}},
+		{{LED: LedFire, LedState: LedOff}},
+	}})
+	for i, expected := range []LedState{LedOn, LedOff, LedOn} {
+		m.Press(4)
+		m.Release(4)
+		m.Tick(at(6000 + i))
+		check(LedFire, expected)
+	}
+
+	// Unbound buttons are ignored
+	m.Unbind(4)
+	m.Press(4)
+	if m.Running(4) {
+		t.Error("Expected unbound macro not to run")
+	}
+
+	for _, macro := range []Macro{
+		{},
+		{Steps: [][]MacroStep{{{Delay: -time.Second}}}},
+		{Steps: [][]MacroStep{{{Line: mfdLines, Text: []byte("X")}}}},
+		{Steps: [][]MacroStep{{{LED: LedA, LedState: LedOn}}}},
+		{Steps: [][]MacroStep{{}}, Cancel: []MacroStep{{LED: LedA, LedState: LedOn}}},
+	} {
+		if err := m.Bind(5, macro); err == nil {
+			t.Errorf("Expected error binding %v", macro)
+		}
+	}
+}
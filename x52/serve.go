@@ -0,0 +1,69 @@
+package x52
+
+import (
+	"io"
+	"net"
+	"sync"
+
+	"nirenjan.org/saitek-x52/x52/sim"
+)
+
+// Serve accepts connections on the listener, and sends the packets received on
+// each connection to the joystick, so that the LEDs and MFD of the joystick can
+// be set from another machine. The remote side connects by setting the
+// X52_SIMULATOR environment variable to tcp:host:port, and then uses the
+// library as it would with a local joystick. Packets that are not part of the
+// simulator protocol are dropped.
+//
+// Serve returns the error from the listener, such as when it is closed. The
+// application must not use the context while it is being served. The
+// connections are not authenticated, so the listener should only be reachable
+// from trusted machines.
+func (ctx *Context) Serve(ln net.Listener) error {
+	var mu sync.Mutex
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+
+		go ctx.serveConn(conn, &mu)
+	}
+}
+
+// serveConn sends the packets received on a single connection to the
+// joystick. The mutex serializes the packets from every connection.
+func (ctx *Context) serveConn(conn net.Conn, mu *sync.Mutex) {
+	defer conn.Close()
+
+	remote := conn.RemoteAddr()
+	ctx.logf(logInfo, "Serving joystick to %v", remote)
+
+	// The state is only used to check that the packets are valid
+	var state sim.State
+	for {
+		p, err := sim.ReadPacket(conn)
+		if err != nil {
+			if err != io.EOF {
+				ctx.logf(logError, "error reading from %v: %v", remote, err)
+			}
+			return
+		}
+
+		if err := state.Apply(p); err != nil {
+			ctx.logf(logWarning, "dropping packet %04x %04x from %v: %v", p.Index, p.Value, remote, err)
+			continue
+		}
+
+		mu.Lock()
+		err = ctx.Raw(p.Index, p.Value)
+		mu.Unlock()
+
+		// Closing the connection tells the remote side that the joystick
+		// has gone away
+		if err != nil {
+			return
+		}
+	}
+}
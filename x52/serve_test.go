@@ -0,0 +1,96 @@
+package x52
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"nirenjan.org/saitek-x52/x52/sim"
+)
+
+// recordDevice records the control packets sent to it
+type recordDevice struct {
+	mu      sync.Mutex
+	packets []sim.Packet
+}
+
+func (dev *recordDevice) Close() error {
+	return nil
+}
+
+func (dev *recordDevice) Control(rType, request uint8, val, idx uint16, data []byte) (int, error) {
+	dev.mu.Lock()
+	defer dev.mu.Unlock()
+
+	dev.packets = append(dev.packets, sim.Packet{Index: idx, Value: val})
+	return 0, nil
+}
+
+func (dev *recordDevice) Reset() error {
+	return nil
+}
+
+func TestServe(t *testing.T) {
+	dev := &recordDevice{}
+	server := NewContext()
+	defer server.Close()
+	server.device = dev
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go server.Serve(ln)
+
+	// Packets outside the protocol are dropped
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.WritePacket(conn, sim.Packet{Index: 0xffff, Value: 0})
+	conn.Close()
+
+	os.Setenv(sim.Env, "tcp:"+ln.Addr().String())
+	defer os.Unsetenv(sim.Env)
+
+	client := NewContext()
+	if !client.Connect() {
+		t.Fatal("Unable to connect to server")
+	}
+	client.SetMFDText(0, []byte("Remote"))
+	client.SetLed(LedA, LedGreen)
+	if err := client.Update(); err != nil {
+		t.Error("Unexpected error", err)
+	}
+	client.Close()
+
+	// Wait for the server to send the packets to the joystick
+	var state sim.State
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		dev.mu.Lock()
+		state = sim.State{}
+		for _, p := range dev.packets {
+			if err := state.Apply(p); err != nil {
+				t.Errorf("Unexpected packet %+v", p)
+			}
+		}
+		dev.mu.Unlock()
+
+		if bytes.Equal(state.Lines[0], []byte("Remote")) && state.LEDs()[1].Color == sim.ColorGreen {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if exp := []byte("Remote"); !bytes.Equal(state.Lines[0], exp) {
+		t.Errorf("Line 1 mismatch, expected %q, got %q", exp, state.Lines[0])
+	}
+	if led := state.LEDs()[1]; led.Name != "A" || led.Color != sim.ColorGreen {
+		t.Errorf("Unexpected LED %+v", led)
+	}
+}
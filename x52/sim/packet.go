@@ -0,0 +1,65 @@
+// Package sim implements the protocol used by the X52 library to drive a
+// simulated X52 Pro instead of the USB device.
+//
+// When the X52_SIMULATOR environment variable is set to the path of a Unix
+// domain socket, or to a TCP address in the form tcp:host:port, the x52
+// library connects to that address instead of opening the USB device, and
+// sends each vendor control packet to the simulator. The simulator decodes the
+// packets into a State, which holds what the joystick would display. The same
+// protocol is used to drive a joystick on another machine, which is served by
+// the Serve method of the x52 context.
+package sim // import "nirenjan.org/saitek-x52/x52/sim"
+
+import (
+	"encoding/binary"
+	"io"
+	"strings"
+)
+
+// Env is the environment variable that holds the address of the simulator
+const Env = "X52_SIMULATOR"
+
+// ParseAddr returns the network and address for the address of a simulator.
+// Addresses in the form tcp:host:port use TCP, and any other address is the
+// path to a Unix domain socket.
+func ParseAddr(addr string) (network, address string) {
+	if strings.HasPrefix(addr, "tcp:") {
+		return "tcp", strings.TrimPrefix(addr, "tcp:")
+	}
+
+	return "unix", addr
+}
+
+// Packet is a single vendor control packet sent to the joystick
+type Packet struct {
+	Index uint16
+	Value uint16
+}
+
+// PacketSize is the size of the encoded packet on the simulator socket
+const PacketSize = 4
+
+// WritePacket writes the packet to w. Packets are encoded as the index
+// followed by the value, each in little endian byte order.
+func WritePacket(w io.Writer, p Packet) error {
+	var buf [PacketSize]byte
+	binary.LittleEndian.PutUint16(buf[0:], p.Index)
+	binary.LittleEndian.PutUint16(buf[2:], p.Value)
+
+	_, err := w.Write(buf[:])
+	return err
+}
+
+// ReadPacket reads a single packet from r. It returns io.EOF only if no
+// bytes of the packet were read.
+func ReadPacket(r io.Reader) (Packet, error) {
+	var buf [PacketSize]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return Packet{}, err
+	}
+
+	return Packet{
+		Index: binary.LittleEndian.Uint16(buf[0:]),
+		Value: binary.LittleEndian.Uint16(buf[2:]),
+	}, nil
+}
@@ -0,0 +1,55 @@
+package sim
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestPacket(t *testing.T) {
+	var buf bytes.Buffer
+	packets := []Packet{{0xd1, 0x6548}, {0xb8, 0x0201}, {0xfd, 0x51}}
+	for _, p := range packets {
+		if err := WritePacket(&buf, p); err != nil {
+			t.Fatal("Unexpected error", err)
+		}
+	}
+
+	if exp := []byte{0xd1, 0, 0x48, 0x65}; !bytes.Equal(buf.Bytes()[:PacketSize], exp) {
+		t.Errorf("Encoding mismatch, expected %#x, got %#x", exp, buf.Bytes()[:PacketSize])
+	}
+
+	for _, exp := range packets {
+		p, err := ReadPacket(&buf)
+		if err != nil {
+			t.Fatal("Unexpected error", err)
+		}
+		if p != exp {
+			t.Errorf("Expected %+v, got %+v", exp, p)
+		}
+	}
+
+	if _, err := ReadPacket(&buf); err != io.EOF {
+		t.Errorf("Expected EOF, got %v", err)
+	}
+
+	buf.Write([]byte{1, 2})
+	if _, err := ReadPacket(&buf); err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected unexpected EOF, got %v", err)
+	}
+}
+
+func TestParseAddr(t *testing.T) {
+	for _, tc := range []struct {
+		addr, network, address string
+	}{
+		{"/tmp/x52sim.sock", "unix", "/tmp/x52sim.sock"},
+		{"tcp:desk:5252", "tcp", "desk:5252"},
+		{"tcp:[::1]:5252", "tcp", "[::1]:5252"},
+	} {
+		network, address := ParseAddr(tc.addr)
+		if network != tc.network || address != tc.address {
+			t.Errorf("%q: expected %v %v, got %v %v", tc.addr, tc.network, tc.address, network, address)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package sim
+
+import (
+	"fmt"
+)
+
+// Number of MFD lines and characters per line
+const (
+	Lines    = 3
+	LineSize = 16
+)
+
+// State is the state of the simulated joystick, as set by the packets that
+// have been applied to it. The zero value is a joystick that has not received
+// any packets.
+type State struct {
+	// Lines holds the text of each MFD line, in the code page of the MFD
+	Lines [Lines][]byte
+
+	// Shift and Blink are the states of the shift indicator on the MFD and
+	// the blinking of the POV hat LED
+	Shift bool
+	Blink bool
+
+	// MFDBrightness and LEDBrightness are the brightness levels
+	MFDBrightness uint16
+	LEDBrightness uint16
+
+	// Clocks holds the clock settings. The primary clock holds the time in
+	// minutes since midnight, and the secondary and tertiary clocks hold the
+	// offset in minutes from the primary clock.
+	Clocks [3]Clock
+
+	// Date holds the fields of the date, in the order they are displayed
+	Date    [3]uint16
+	DateSet bool
+
+	leds uint32
+}
+
+// Clock is the state of a single clock on the MFD
+type Clock struct {
+	Set     bool
+	Hour24  bool
+	Minutes int
+}
+
+// LEDColor is the color of an LED
+type LEDColor uint
+
+// LED colors. On/off LEDs only use ColorOff and ColorOn, while the remaining
+// LEDs use every color except ColorOn.
+const (
+	ColorOff LEDColor = iota
+	ColorOn
+	ColorRed
+	ColorAmber
+	ColorGreen
+)
+
+// String returns a string representation of the LED color
+func (c LEDColor) String() string {
+	switch c {
+	case ColorOff:
+		return "off"
+	case ColorOn:
+		return "on"
+	case ColorRed:
+		return "red"
+	case ColorAmber:
+		return "amber"
+	case ColorGreen:
+		return "green"
+	}
+
+	return fmt.Sprintf("LEDColor(%d)", c)
+}
+
+// LED is the state of a single LED
+type LED struct {
+	Name  string
+	Color LEDColor
+}
+
+// ledInfo lists the LEDs in the order they appear on the joystick, with the
+// LED bit used in the 0xb8 packet. Bicolor LEDs use the bit for red, and the
+// next bit for green.
+var ledInfo = []struct {
+	name    string
+	bit     uint
+	bicolor bool
+}{
+	{"FIRE", 0x01, false},
+	{"A", 0x02, true},
+	{"B", 0x04, true},
+	{"D", 0x06, true},
+	{"E", 0x08, true},
+	{"T1", 0x0a, true},
+	{"T2", 0x0c, true},
+	{"T3", 0x0e, true},
+	{"POV", 0x10, true},
+	{"CLUTCH", 0x12, true},
+	{"THROTTLE", 0x14, false},
+}
+
+// Packet indices
+const (
+	indexShift         = 0xfd
+	indexBlink         = 0xb4
+	indexLED           = 0xb8
+	indexMFDBrightness = 0xb1
+	indexLEDBrightness = 0xb2
+	indexTime          = 0xc0
+	indexOffset2       = 0xc1
+	indexOffset3       = 0xc2
+	indexDate          = 0xc4
+	indexYear          = 0xc8
+	indexLineWrite     = 0xd0
+	indexLineClear     = 0xd8
+)
+
+// Apply updates the state with the packet. It returns an error if the packet
+// is not recognized, in which case the state is unchanged.
+func (s *State) Apply(p Packet) error {
+	switch p.Index {
+	case indexShift:
+		s.Shift = p.Value&1 != 0
+
+	case indexBlink:
+		s.Blink = p.Value&1 != 0
+
+	case indexLED:
+		bit := uint(p.Value >> 8)
+		if bit >= 32 {
+			return fmt.Errorf("sim: invalid LED %#x", bit)
+		}
+		if p.Value&1 != 0 {
+			s.leds |= 1 << bit
+		} else {
+			s.leds &^= 1 << bit
+		}
+
+	case indexMFDBrightness:
+		s.MFDBrightness = p.Value
+
+	case indexLEDBrightness:
+		s.LEDBrightness = p.Value
+
+	case indexTime:
+		s.Clocks[0] = Clock{
+			Set:     true,
+			Hour24:  p.Value&0x8000 != 0,
+			Minutes: int((p.Value>>8)&0x7f)*60 + int(p.Value&0xff),
+		}
+
+	case indexOffset2, indexOffset3:
+		offset := int(p.Value & 0x3ff)
+		if p.Value&0x400 != 0 {
+			offset = -offset
+		}
+		s.Clocks[p.Index-indexTime] = Clock{
+			Set:     true,
+			Hour24:  p.Value&0x8000 != 0,
+			Minutes: offset,
+		}
+
+	case indexDate:
+		s.Date[0] = p.Value & 0xff
+		s.Date[1] = p.Value >> 8
+		s.DateSet = true
+
+	case indexYear:
+		s.Date[2] = p.Value
+		s.DateSet = true
+
+	default:
+		line, ok := lineIndex(p.Index)
+		if !ok {
+			return fmt.Errorf("sim: unknown packet %04x %04x", p.Index, p.Value)
+		}
+
+		if p.Index&0xf8 == indexLineClear {
+			s.Lines[line] = s.Lines[line][:0]
+		} else if len(s.Lines[line]) < LineSize {
+			s.Lines[line] = append(s.Lines[line], byte(p.Value), byte(p.Value>>8))
+		}
+	}
+
+	return nil
+}
+
+// lineIndex returns the MFD line addressed by a line clear or write packet
+func lineIndex(index uint16) (int, bool) {
+	if index&0xf8 != indexLineClear && index&0xf8 != indexLineWrite {
+		return 0, false
+	}
+
+	switch index & 0x07 {
+	case 1:
+		return 0, true
+	case 2:
+		return 1, true
+	case 4:
+		return 2, true
+	}
+
+	return 0, false
+}
+
+// Clone returns a copy of the state that does not share the line buffers, so
+// that it can be read while further packets are applied to the original
+func (s *State) Clone() State {
+	c := *s
+	for i := range c.Lines {
+		c.Lines[i] = append([]byte(nil), s.Lines[i]...)
+	}
+	return c
+}
+
+// LEDs returns the state of every LED, in the order they appear on the
+// joystick
+func (s *State) LEDs() []LED {
+	leds := make([]LED, 0, len(ledInfo))
+	for _, info := range ledInfo {
+		red := s.leds&(1<<info.bit) != 0
+		led := LED{Name: info.name}
+
+		switch {
+		case !info.bicolor:
+			if red {
+				led.Color = ColorOn
+			}
+		case red && s.leds&(1<<(info.bit+1)) != 0:
+			led.Color = ColorAmber
+		case red:
+			led.Color = ColorRed
+		case s.leds&(1<<(info.bit+1)) != 0:
+			led.Color = ColorGreen
+		}
+
+		leds = append(leds, led)
+	}
+
+	return leds
+}
+
+// ClockTime returns the time shown on the given clock, in the range 0-2 for
+// the primary, secondary and tertiary clocks. The secondary and tertiary
+// clocks are computed from the primary clock and their offsets. It returns
+// false if the clock, or the primary clock, has not been set.
+func (s *State) ClockTime(clock int) (hour, minute int, ok bool) {
+	if clock < 0 || clock >= len(s.Clocks) || !s.Clocks[0].Set || !s.Clocks[clock].Set {
+		return 0, 0, false
+	}
+
+	minutes := s.Clocks[0].Minutes
+	if clock != 0 {
+		minutes += s.Clocks[clock].Minutes
+	}
+
+	minutes %= 24 * 60
+	if minutes < 0 {
+		minutes += 24 * 60
+	}
+
+	return minutes / 60, minutes % 60, true
+}
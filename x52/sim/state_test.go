@@ -0,0 +1,148 @@
+package sim
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestApplyLines(t *testing.T) {
+	var s State
+	packets := []Packet{
+		{0xd9, 0},
+		{0xd1, 'e'<<8 | 'H'},
+		{0xd1, 'l'<<8 | 'l'},
+		{0xd1, 'o'},
+		{0xdc, 0},
+		{0xd4, 'y'<<8 | 'x'},
+	}
+	for _, p := range packets {
+		if err := s.Apply(p); err != nil {
+			t.Fatal("Unexpected error", err)
+		}
+	}
+
+	if exp := []byte("Hello\x00"); !bytes.Equal(s.Lines[0], exp) {
+		t.Errorf("Line 1 mismatch, expected %q, got %q", exp, s.Lines[0])
+	}
+	if len(s.Lines[1]) != 0 {
+		t.Errorf("Line 2 mismatch, got %q", s.Lines[1])
+	}
+	if exp := []byte("xy"); !bytes.Equal(s.Lines[2], exp) {
+		t.Errorf("Line 3 mismatch, expected %q, got %q", exp, s.Lines[2])
+	}
+
+	// Clearing the line discards the previous contents, and writes beyond
+	// the line length are ignored
+	s.Apply(Packet{0xd9, 0})
+	for i := 0; i < 10; i++ {
+		s.Apply(Packet{0xd1, 'b'<<8 | 'a'})
+	}
+	if exp := bytes.Repeat([]byte("ab"), 8); !bytes.Equal(s.Lines[0], exp) {
+		t.Errorf("Line 1 mismatch, expected %q, got %q", exp, s.Lines[0])
+	}
+
+	// A clone is not affected by packets applied to the original
+	c := s.Clone()
+	s.Apply(Packet{0xd9, 0})
+	s.Apply(Packet{0xd1, 'd'<<8 | 'c'})
+	if exp := bytes.Repeat([]byte("ab"), 8); !bytes.Equal(c.Lines[0], exp) {
+		t.Errorf("Clone mismatch, expected %q, got %q", exp, c.Lines[0])
+	}
+}
+
+func TestApplyLEDs(t *testing.T) {
+	var s State
+	packets := []Packet{
+		{0xb8, 0x0101}, // Fire on
+		{0xb8, 0x0201}, // A red
+		{0xb8, 0x0300},
+		{0xb8, 0x0400}, // B green
+		{0xb8, 0x0501},
+		{0xb8, 0x0601}, // D amber
+		{0xb8, 0x0701},
+		{0xb8, 0x1401}, // Throttle on
+		{0xb8, 0x1400}, // Throttle off
+		{0xfd, 0x51},
+		{0xb4, 0x50},
+	}
+	for _, p := range packets {
+		if err := s.Apply(p); err != nil {
+			t.Fatal("Unexpected error", err)
+		}
+	}
+
+	exp := map[string]LEDColor{
+		"FIRE":     ColorOn,
+		"A":        ColorRed,
+		"B":        ColorGreen,
+		"D":        ColorAmber,
+		"THROTTLE": ColorOff,
+	}
+	leds := s.LEDs()
+	if len(leds) != 11 {
+		t.Fatalf("Expected 11 LEDs, got %d", len(leds))
+	}
+	for _, led := range leds {
+		if led.Color != exp[led.Name] {
+			t.Errorf("LED %v expected %v, got %v", led.Name, exp[led.Name], led.Color)
+		}
+	}
+
+	if !s.Shift || s.Blink {
+		t.Errorf("Unexpected shift %v, blink %v", s.Shift, s.Blink)
+	}
+}
+
+func TestApplyClocks(t *testing.T) {
+	var s State
+	if _, _, ok := s.ClockTime(0); ok {
+		t.Error("Unset clock reported as set")
+	}
+
+	packets := []Packet{
+		{0xc0, 0x8000 | 23<<8 | 45}, // 23:45, 24 hour
+		{0xc1, 0x0400 | 90},         // -1:30
+		{0xc2, 30},                  // +0:30, 12 hour
+		{0xc4, 10<<8 | 19},
+		{0xc8, 26},
+		{0xb1, 0x40},
+		{0xb2, 0x7f},
+	}
+	for _, p := range packets {
+		if err := s.Apply(p); err != nil {
+			t.Fatal("Unexpected error", err)
+		}
+	}
+
+	tests := []struct {
+		hour, minute int
+		hour24       bool
+	}{
+		{23, 45, true},
+		{22, 15, false},
+		{0, 15, false},
+	}
+	for i, tc := range tests {
+		h, m, ok := s.ClockTime(i)
+		if !ok || h != tc.hour || m != tc.minute || s.Clocks[i].Hour24 != tc.hour24 {
+			t.Errorf("Clock %d expected %02d:%02d (%v), got %02d:%02d (%v) %v",
+				i+1, tc.hour, tc.minute, tc.hour24, h, m, s.Clocks[i].Hour24, ok)
+		}
+	}
+
+	if !s.DateSet || s.Date != [3]uint16{19, 10, 26} {
+		t.Errorf("Unexpected date %v", s.Date)
+	}
+	if s.MFDBrightness != 0x40 || s.LEDBrightness != 0x7f {
+		t.Errorf("Unexpected brightness %v %v", s.MFDBrightness, s.LEDBrightness)
+	}
+}
+
+func TestApplyUnknown(t *testing.T) {
+	var s State
+	for _, p := range []Packet{{0x12, 0}, {0xd3, 0}, {0xd8, 0}, {0xb8, 0x2001}} {
+		if err := s.Apply(p); err == nil {
+			t.Errorf("Expected error for packet %+v", p)
+		}
+	}
+}
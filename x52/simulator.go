@@ -0,0 +1,59 @@
+package x52
+
+import (
+	"net"
+	"os"
+
+	"github.com/google/gousb"
+	"nirenjan.org/saitek-x52/x52/sim"
+)
+
+// simDevice sends the vendor control packets to a simulator over a socket,
+// instead of to the USB device
+type simDevice struct {
+	conn net.Conn
+}
+
+// Close closes the connection to the simulator
+func (dev *simDevice) Close() error {
+	return dev.conn.Close()
+}
+
+// Control sends the index and value of the control packet to the simulator.
+// The simulator going away is treated the same as unplugging the joystick.
+func (dev *simDevice) Control(rType, request uint8, val, idx uint16, data []byte) (int, error) {
+	if err := sim.WritePacket(dev.conn, sim.Packet{Index: idx, Value: val}); err != nil {
+		return 0, gousb.ErrorNoDevice
+	}
+
+	return 0, nil
+}
+
+// Reset does nothing, since the simulator has no device to reset
+func (dev *simDevice) Reset() error {
+	return nil
+}
+
+// simulatorPath returns the address of the simulator, or an empty string if
+// the simulator is not in use
+func simulatorPath() string {
+	return os.Getenv(sim.Env)
+}
+
+// connectSimulator connects to the simulator listening on the given address,
+// which may also be a joystick served by another machine. The simulator
+// behaves as an X52 Pro.
+func (ctx *Context) connectSimulator(addr string) bool {
+	network, address := sim.ParseAddr(addr)
+	conn, err := net.Dial(network, address)
+	if err != nil {
+		ctx.logf(logError, "error connecting to simulator: %v", err)
+		return false
+	}
+
+	ctx.logf(logInfo, "Connected to simulator at %v", addr)
+	ctx.device = &simDevice{conn}
+	bitSet(&ctx.featureFlags, FeatureLED)
+
+	return true
+}
@@ -0,0 +1,92 @@
+package x52
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"nirenjan.org/saitek-x52/x52/sim"
+)
+
+func TestSimulator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "x52sim")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sim.sock")
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	// Apply every packet that the library sends until it disconnects
+	done := make(chan sim.State)
+	go func() {
+		var state sim.State
+		conn, err := ln.Accept()
+		if err == nil {
+			for {
+				p, err := sim.ReadPacket(conn)
+				if err != nil {
+					break
+				}
+				state.Apply(p)
+			}
+			conn.Close()
+		}
+		done <- state
+	}()
+
+	os.Setenv(sim.Env, path)
+	defer os.Unsetenv(sim.Env)
+
+	ctx := NewContext()
+	if !ctx.Connect() {
+		t.Fatal("Unable to connect to simulator")
+	}
+	if !ctx.HasFeature(FeatureLED) {
+		t.Error("Simulator does not support LEDs")
+	}
+
+	ctx.SetMFDText(0, []byte("Hello"))
+	ctx.SetMFDText(2, []byte("World!"))
+	ctx.SetLed(LedA, LedGreen)
+	ctx.SetLed(LedFire, LedOn)
+	ctx.SetBlink(true)
+	ctx.SetMFDBrightness(0x40)
+	if err := ctx.Update(); err != nil {
+		t.Error("Unexpected error", err)
+	}
+	ctx.Close()
+
+	state := <-done
+	if exp := []byte("Hello\x00"); !bytes.Equal(state.Lines[0], exp) {
+		t.Errorf("Line 1 mismatch, expected %q, got %q", exp, state.Lines[0])
+	}
+	if exp := []byte("World!"); !bytes.Equal(state.Lines[2], exp) {
+		t.Errorf("Line 3 mismatch, expected %q, got %q", exp, state.Lines[2])
+	}
+
+	for _, led := range state.LEDs() {
+		exp := sim.ColorOff
+		switch led.Name {
+		case "A":
+			exp = sim.ColorGreen
+		case "FIRE":
+			exp = sim.ColorOn
+		}
+		if led.Color != exp {
+			t.Errorf("LED %v expected %v, got %v", led.Name, exp, led.Color)
+		}
+	}
+
+	if !state.Blink || state.Shift || state.MFDBrightness != 0x40 {
+		t.Errorf("Unexpected state %+v", state)
+	}
+}
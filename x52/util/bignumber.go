@@ -0,0 +1,93 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+)
+
+// BigDigitWidth and BigDigitHeight are the dimensions in characters of a
+// single digit in a BigNumber
+const (
+	BigDigitWidth  = 3
+	BigDigitHeight = 2
+)
+
+// bigGlyphs are the big versions of the characters supported by BigNumber.
+// Digits are drawn like a seven segment display, with the vertical line for
+// the vertical segments, the overline and low line for the top, middle and
+// bottom segments, and the capital Xi for the top and middle segments
+// together. The period and colon are a single character wide.
+var bigGlyphs = map[rune][BigDigitHeight][]byte{
+	'0': {{'|', 0xFF, '|'}, {'|', '_', '|'}},
+	'1': {{' ', ' ', '|'}, {' ', ' ', '|'}},
+	'2': {{' ', 0x18, '|'}, {'|', '_', ' '}},
+	'3': {{' ', 0x18, '|'}, {' ', '_', '|'}},
+	'4': {{'|', '_', '|'}, {' ', ' ', '|'}},
+	'5': {{'|', 0x18, ' '}, {' ', '_', '|'}},
+	'6': {{'|', 0x18, ' '}, {'|', '_', '|'}},
+	'7': {{' ', 0xFF, '|'}, {' ', ' ', '|'}},
+	'8': {{'|', 0x18, '|'}, {'|', '_', '|'}},
+	'9': {{'|', 0x18, '|'}, {' ', '_', '|'}},
+	'-': {{' ', '_', ' '}, {' ', ' ', ' '}},
+	' ': {{' ', ' ', ' '}, {' ', ' ', ' '}},
+	'.': {{' '}, {'.'}},
+	':': {{0xA5}, {0xA5}},
+}
+
+// BigNumber is a Widget that shows a number in big digits over two lines of
+// the MFD. Each digit, the minus sign and the space are BigDigitWidth
+// characters wide, and the period and colon are a single character wide, so
+// a full line fits five digits, or a time such as 12:34.
+type BigNumber struct {
+	width int
+	lines [BigDigitHeight][]byte
+}
+
+// NewBigNumber returns a blank BigNumber of the given width in characters
+func NewBigNumber(width int) (*BigNumber, error) {
+	if width < 1 || width > mfdLineSize {
+		return nil, errors.New("width out of range")
+	}
+
+	return &BigNumber{width: width}, nil
+}
+
+// SetText sets the text shown, which may contain digits, spaces, and the
+// characters "-.:". The text is right aligned, and the widget is unchanged if
+// the text is too wide or contains any other character.
+func (bn *BigNumber) SetText(s string) error {
+	var lines [BigDigitHeight][]byte
+	for _, r := range s {
+		glyph, ok := bigGlyphs[r]
+		if !ok {
+			return errors.New("unsupported character in big number")
+		}
+
+		for i := range lines {
+			lines[i] = append(lines[i], glyph[i]...)
+		}
+	}
+
+	if len(lines[0]) > bn.width {
+		return errors.New("text does not fit in big number")
+	}
+
+	bn.lines = lines
+	return nil
+}
+
+// Size returns the size of the widget
+func (bn *BigNumber) Size() (width, height int) {
+	return bn.width, BigDigitHeight
+}
+
+// Draw returns the big digits for the current text
+func (bn *BigNumber) Draw() [][]byte {
+	out := make([][]byte, BigDigitHeight)
+	for i, data := range bn.lines {
+		out[i] = bytes.Repeat([]byte{0x20}, bn.width)
+		copy(out[i][bn.width-len(data):], data)
+	}
+
+	return out
+}
@@ -0,0 +1,43 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestBigNumber(t *testing.T) {
+	bn, err := NewBigNumber(mfdLineSize)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	if err := bn.SetText("12:30"); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	expected := []string{
+		"     | \x18|\xa5 \x18||\xff|",
+		"     ||_ \xa5 _||_|",
+	}
+
+	lines := bn.Draw()
+	for i, exp := range expected {
+		if string(lines[i]) != exp {
+			t.Errorf("Line %d mismatch, expected %q, got %q", i, exp, lines[i])
+		}
+	}
+
+	// Invalid text leaves the widget unchanged
+	if err := bn.SetText("1A"); err == nil {
+		t.Error("Expected error for unsupported character")
+	}
+	if err := bn.SetText("123456"); err == nil {
+		t.Error("Expected error for text that does not fit")
+	}
+	if got := bn.Draw()[1]; string(got) != expected[1] {
+		t.Errorf("Widget changed after error, got %q", got)
+	}
+
+	if _, err := NewBigNumber(0); err == nil {
+		t.Error("Expected error for zero width")
+	}
+}
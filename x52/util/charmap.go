@@ -1,35 +1,220 @@
 package util
 
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
 // ConvertStringToX52Charmap converts a string to a byte slice that is accepted
 // by the X52 MFD display. If replace is true, then runes that are not
 // recognized by this function are replaced with the replacement byte, otherwise
 // they are dropped from the output
 func ConvertStringToX52Charmap(s string, replace bool, c byte) []byte {
-	out := make([]byte, 0, len(s))
-	for _, r := range s {
-		ch, ok := charmap[r]
-		if !ok && replace {
-			ok = replace
-			ch = c
-		}
-		if ok {
-			out = append(out, ch)
-		}
+	var flags ConvertFlags
+	if replace {
+		flags |= ConvertReplace
 	}
 
-	return out
+	return ConvertString(s, flags, c)
+}
+
+// AppendX52Charmap converts a string in the same way as ConvertString, and
+// appends the result to dst, returning the extended slice. Reusing the same
+// buffer across calls avoids allocating a new slice for every conversion.
+func AppendX52Charmap(dst []byte, s string, flags ConvertFlags, c byte) []byte {
+	return builtinCharmap.Append(dst, s, flags, c)
+}
+
+// ConvertFlags control the conversion of strings to the MFD code page
+type ConvertFlags uint
+
+const (
+	// ConvertReplace replaces runes that are not recognized with the
+	// replacement byte, instead of dropping them from the output
+	ConvertReplace ConvertFlags = 1 << iota
+
+	// ConvertTransliterate converts runes that are not in the character map
+	// to a close match before falling back to the replacement byte. This
+	// strips diacritics, converts typographic punctuation to ASCII, and
+	// transliterates Greek and Cyrillic letters to Latin.
+	ConvertTransliterate
+
+	// ConvertKana converts hiragana and full-width katakana to the
+	// half-width katakana in the character map, splitting voiced syllables
+	// into the base syllable and the voiced sound mark. Full-width forms of
+	// ASCII characters are also converted to ASCII.
+	ConvertKana
+)
+
+// ConvertString converts a string to a byte slice that is accepted by the X52
+// MFD display, using the given flags. The byte c is used as the replacement
+// byte if the ConvertReplace flag is set.
+func ConvertString(s string, flags ConvertFlags, c byte) []byte {
+	return builtinCharmap.Convert(s, flags, c)
 }
 
 // ReplaceMissing is the default replacement character for unsupported Unicode
 // code points
 const ReplaceMissing byte = 0xDB
 
+// DecodeX52Charmap converts a byte slice in the code page of the X52 MFD
+// display to a string. Bytes that have no corresponding entry in the character
+// map are decoded as DecodeMissing.
+func DecodeX52Charmap(b []byte) string {
+	return builtinCharmap.Decode(b)
+}
+
+// DecodeMissing is the rune used when decoding bytes that are not listed in
+// the character map
+const DecodeMissing rune = utf8.RuneError
+
+// Charmap is a conversion table between Unicode and the code page of the X52
+// MFD display. Use NewCharmap to create a Charmap that starts with the built-in
+// character map and can be extended at runtime. The zero value is an empty
+// character map, which converts nothing until entries are added.
+type Charmap struct {
+	table map[rune]byte
+	pages [256]*charmapPage
+
+	// reverse holds the rune for each MFD byte plus one, so that the zero
+	// value marks bytes that are not in the character map
+	reverse [256]rune
+}
+
+// charmapPage is a dense lookup table for 256 consecutive code points in the
+// Basic Multilingual Plane. Each entry holds the MFD byte plus one, so that
+// the zero value marks code points that are not in the character map.
+type charmapPage [256]uint16
+
+// builtinCharmap is the character map used by the package level functions.
+// The reverse table is generated from the character map below, so that both
+// directions of the conversion are always consistent.
+var builtinCharmap = func() *Charmap {
+	cm := &Charmap{table: charmap}
+	for r, c := range charmap {
+		cm.reverse[c] = r + 1
+		cm.setPage(r, c)
+	}
+
+	return cm
+}()
+
+// Convert converts a string to a byte slice that is accepted by the X52 MFD
+// display, using the given flags. The byte c is used as the replacement byte
+// if the ConvertReplace flag is set.
+func (cm *Charmap) Convert(s string, flags ConvertFlags, c byte) []byte {
+	return cm.Append(make([]byte, 0, len(s)), s, flags, c)
+}
+
+// Append converts a string in the same way as Convert, and appends the result
+// to dst, returning the extended slice. Append does not allocate if dst has
+// sufficient capacity, except when transliterating runes that are not in the
+// character map.
+func (cm *Charmap) Append(dst []byte, s string, flags ConvertFlags, c byte) []byte {
+	if flags&ConvertTransliterate != 0 {
+		// Compose any combining sequences first, since the character map
+		// has entries for many precomposed characters
+		s = norm.NFC.String(s)
+	}
+
+	for _, r := range s {
+		dst = cm.appendRune(dst, r, flags, c)
+	}
+
+	return dst
+}
+
+// Decode converts a byte slice in the code page of the X52 MFD display to a
+// string. Bytes that have no corresponding entry in the character map are
+// decoded as DecodeMissing.
+func (cm *Charmap) Decode(b []byte) string {
+	var sb strings.Builder
+	sb.Grow(len(b))
+	for _, c := range b {
+		r := DecodeMissing
+		if v := cm.reverse[c]; v != 0 {
+			r = v - 1
+		}
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+// lookup returns the MFD byte for the rune, using the dense page tables for
+// code points in the Basic Multilingual Plane
+func (cm *Charmap) lookup(r rune) (byte, bool) {
+	if r >= 0 && r <= 0xFFFF {
+		if page := cm.pages[r>>8]; page != nil {
+			if v := page[r&0xFF]; v != 0 {
+				return byte(v - 1), true
+			}
+		}
+		return 0, false
+	}
+
+	c, ok := cm.table[r]
+	return c, ok
+}
+
+// setPage updates the dense page table entry for the rune. Code points outside
+// the Basic Multilingual Plane are only stored in the map.
+func (cm *Charmap) setPage(r rune, c byte) {
+	if r < 0 || r > 0xFFFF {
+		return
+	}
+
+	page := cm.pages[r>>8]
+	if page == nil {
+		page = new(charmapPage)
+		cm.pages[r>>8] = page
+	}
+	page[r&0xFF] = uint16(c) + 1
+}
+
+// appendRune appends the converted rune to out
+func (cm *Charmap) appendRune(out []byte, r rune, flags ConvertFlags, c byte) []byte {
+	if ch, ok := cm.lookup(r); ok {
+		return append(out, ch)
+	}
+
+	if flags&ConvertKana != 0 {
+		if k, ok := convertKana(r); ok {
+			for _, kr := range k {
+				out = cm.appendRune(out, kr, flags, c)
+			}
+			return out
+		}
+	}
+
+	if flags&ConvertTransliterate != 0 {
+		if t, ok := transliterate(r); ok {
+			// The result of the transliteration is either in the
+			// character map, or has no further transliteration, so
+			// this recursion always terminates
+			for _, tr := range t {
+				out = cm.appendRune(out, tr, flags, c)
+			}
+			return out
+		}
+	}
+
+	if flags&ConvertReplace != 0 {
+		out = append(out, c)
+	}
+
+	return out
+}
+
 // Conversion Map for X52 Pro MFD character map
 
 // The X52 Pro MFD uses a single byte character set and encodes multiple
 // character ranges in that set. This file defines the mapping from Unicode
-// code points to the vendor character set. This is transformed at compilation
-// time to a lookup table using UTF-8. All characters must be explicitly
+// code points to the vendor character set. This is transformed at package
+// initialization to a dense lookup table, with one page of 256 entries for
+// each block of code points in use. All characters must be explicitly
 // specified to be added to the lookup table.
 
 // Lines must be formatted as follows
@@ -44,7 +229,8 @@ const ReplaceMissing byte = 0xDB
 // 0xDB which is the entry in the character map for a box (similar to U+25A1)
 
 // Note that the library will not attempt to perform any additional matching
-// steps like iconv does to find a close match in the glyph, so if you need
+// steps like iconv does to find a close match in the glyph unless the
+// ConvertTransliterate flag is given (see translit.go), so if you need
 // to add any such "close matches", you will need to explicitly list them
 // in the list below.
 
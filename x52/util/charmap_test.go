@@ -55,8 +55,70 @@ func TestLongString(t *testing.T) {
 	}
 }
 
+func TestDecode(t *testing.T) {
+	// Every byte that appears in the map must decode to the rune that maps
+	// onto it, which also requires that no two runes map to the same byte
+	seen := make(map[byte]rune)
+	for k, v := range charmap {
+		if r, ok := seen[v]; ok {
+			t.Errorf("Byte %#x is mapped from both %U and %U", v, r, k)
+		}
+		seen[v] = k
+
+		s := DecodeX52Charmap([]byte{v})
+		if s != string(k) {
+			t.Errorf("Mismatch in decoding %#x, expected %U, got %q", v, k, s)
+		}
+	}
+
+	// Bytes that are not in the map decode to the missing marker
+	for i := 0; i < 256; i++ {
+		if _, ok := seen[byte(i)]; ok {
+			continue
+		}
+
+		s := DecodeX52Charmap([]byte{byte(i)})
+		if s != string(DecodeMissing) {
+			t.Errorf("Unmapped byte %#x decoded to %q", i, s)
+		}
+	}
+
+	s := "Zürich ½ \u03a9 ｱｲｳ"
+	b := ConvertStringToX52Charmap(s, true, ReplaceMissing)
+	if got := DecodeX52Charmap(b); got != s {
+		t.Errorf("Round trip mismatch, expected %q, got %q", s, got)
+	}
+}
+
 func BenchmarkConvert(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		ConvertStringToX52Charmap("\uFF71\uFF72\uFF73\uFF74\uFF75", false, 0)
 	}
 }
+
+func TestAppend(t *testing.T) {
+	s := "Zürich ½ Ω ｱｲｳ \U0001F600"
+	exp := ConvertStringToX52Charmap(s, true, ReplaceMissing)
+
+	buf := []byte("> ")
+	got := AppendX52Charmap(buf, s, ConvertReplace, ReplaceMissing)
+	if !bytes.Equal(got[2:], exp) || string(got[:2]) != "> " {
+		t.Errorf("Append mismatch, expected %#x, got %#x", exp, got)
+	}
+
+	// Appending into a buffer with sufficient capacity must not allocate
+	buf = make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = AppendX52Charmap(buf[:0], s, ConvertReplace, ReplaceMissing)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+}
+
+func BenchmarkAppend(b *testing.B) {
+	buf := make([]byte, 0, 16)
+	for i := 0; i < b.N; i++ {
+		buf = AppendX52Charmap(buf[:0], "ｱｲｳｴｵ", 0, 0)
+	}
+}
@@ -0,0 +1,176 @@
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NewCharmap returns a Charmap that starts with the built-in character map,
+// and which can be extended or overridden with Set, Load or LoadFile
+func NewCharmap() *Charmap {
+	cm := &Charmap{
+		table:   make(map[rune]byte, len(builtinCharmap.table)),
+		reverse: builtinCharmap.reverse,
+	}
+
+	for r, c := range builtinCharmap.table {
+		cm.table[r] = c
+	}
+
+	// Copy the pages, so that Set does not modify the built-in map
+	for i, page := range builtinCharmap.pages {
+		if page != nil {
+			p := *page
+			cm.pages[i] = &p
+		}
+	}
+
+	return cm
+}
+
+// Set maps the rune to the given byte in the MFD code page, replacing any
+// existing mapping for the rune. Decoding the byte only returns the rune if the
+// byte was not already mapped from some other rune.
+func (cm *Charmap) Set(r rune, c byte) {
+	if cm.table == nil {
+		cm.table = make(map[rune]byte)
+	}
+
+	cm.table[r] = c
+	cm.setPage(r, c)
+	if cm.reverse[c] == 0 {
+		cm.reverse[c] = r + 1
+	}
+}
+
+// Load reads character map entries from the reader, in the same format as the
+// built-in character map. Each line must be formatted as follows
+//
+//	<Unicode code point in hex>: <MFD charmap value in hex>
+//
+// or
+//
+//	<Unicode code point in hex> <MFD charmap value as single character>
+//
+// Hex values may optionally be prefixed with 0x, and code points with U+.
+// Blank lines and lines beginning with // are ignored, as are comments
+// beginning with // after the charmap value. A trailing comma after the value
+// is permitted, so that lines may be copied from the built-in map.
+func (cm *Charmap) Load(rd io.Reader) error {
+	scanner := bufio.NewScanner(rd)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		r, c, err := cm.parseLine(line)
+		if err != nil {
+			return fmt.Errorf("line %d: %v", lineNum, err)
+		}
+
+		cm.Set(r, c)
+	}
+
+	return scanner.Err()
+}
+
+// LoadFile reads character map entries from the named file. See Load for the
+// format of the file.
+func (cm *Charmap) LoadFile(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := cm.Load(f); err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+
+	return nil
+}
+
+// parseLine parses a single non-empty line of a character map file
+func (cm *Charmap) parseLine(line string) (rune, byte, error) {
+	sep := strings.IndexFunc(line, func(r rune) bool {
+		return r == ':' || unicode.IsSpace(r)
+	})
+	if sep < 0 {
+		return 0, 0, fmt.Errorf("missing charmap value")
+	}
+
+	r, err := parseCodePoint(line[:sep])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	value := strings.TrimLeftFunc(line[sep:], unicode.IsSpace)
+	if strings.HasPrefix(value, ":") && !isCharValue(value[1:]) {
+		// Hex value, ignore any trailing comment and comma
+		value = value[1:]
+		if i := strings.Index(value, "//"); i >= 0 {
+			value = value[:i]
+		}
+		value = strings.TrimSpace(value)
+		value = strings.TrimSpace(strings.TrimSuffix(value, ","))
+		value = strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+
+		c, err := strconv.ParseUint(value, 16, 8)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid charmap value %q", value)
+		}
+
+		return r, byte(c), nil
+	}
+
+	// Single character value, which must already be in the character map
+	ch, size := utf8.DecodeRuneInString(value)
+	if size == 0 || ch == utf8.RuneError {
+		return 0, 0, fmt.Errorf("missing charmap value")
+	}
+
+	rest := strings.TrimSpace(value[size:])
+	rest = strings.TrimSpace(strings.TrimPrefix(rest, ","))
+	if rest != "" && !strings.HasPrefix(rest, "//") {
+		return 0, 0, fmt.Errorf("unexpected text %q after charmap value", rest)
+	}
+
+	c, ok := cm.lookup(ch)
+	if !ok {
+		return 0, 0, fmt.Errorf("character %q is not in the character map", ch)
+	}
+
+	return r, c, nil
+}
+
+// isCharValue returns true if the text following a colon is empty, or only
+// a comment, which means that the colon itself is the single character value
+func isCharValue(s string) bool {
+	s = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s), ","))
+	return s == "" || strings.HasPrefix(s, "//")
+}
+
+// parseCodePoint parses a Unicode code point in hex
+func parseCodePoint(s string) (rune, error) {
+	hex := s
+	for _, prefix := range []string{"0x", "0X", "U+", "u+"} {
+		hex = strings.TrimPrefix(hex, prefix)
+	}
+
+	cp, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || !utf8.ValidRune(rune(cp)) {
+		return 0, fmt.Errorf("invalid code point %q", s)
+	}
+
+	return rune(cp), nil
+}
@@ -0,0 +1,96 @@
+package util
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCharmapLoad(t *testing.T) {
+	input := `
+// Close matches for symbols
+0x2191: 0x5e, // UPWARDS ARROW
+2193: 76
+U+00B0: 0xDF  // DEGREE SIGN
+0x2500 -        // BOX DRAWINGS LIGHT HORIZONTAL
+2502 |
+2261 ≪, // copied from another mapping
+003A: 0x2E // Override the colon
+FF1A :
+`
+
+	cm := NewCharmap()
+	if err := cm.Load(strings.NewReader(input)); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	got := cm.Convert("↑↓°─│≡:：", 0, 0)
+	exp := []byte{0x5E, 0x76, 0xDF, 0x2D, 0x7C, 0xFB, 0x2E, 0x2E}
+	if !bytes.Equal(got, exp) {
+		t.Errorf("Conversion mismatch, expected %#x, got %#x", exp, got)
+	}
+
+	// Existing decodings are not changed by the overrides
+	if got := cm.Decode([]byte{0x5E, 0xDF, 0x2E}); got != "^ﾟ." {
+		t.Errorf("Decode mismatch, got %q", got)
+	}
+
+	// The built-in character map is not modified
+	if got := ConvertString("↑°:", 0, 0); !bytes.Equal(got, []byte(":")) {
+		t.Errorf("Built-in map was modified, got %#x", got)
+	}
+}
+
+func TestCharmapSet(t *testing.T) {
+	cm := NewCharmap()
+
+	// Bytes without a mapping decode to the new rune
+	cm.Set('\\', 0xF0)
+	cm.Set('~', 0xF1)
+	if got := cm.Decode([]byte{0xF0, 0xF1}); got != "\\~" {
+		t.Errorf("Decode mismatch, got %q", got)
+	}
+	if got := cm.Convert("\\~", 0, 0); !bytes.Equal(got, []byte{0xF0, 0xF1}) {
+		t.Errorf("Convert mismatch, got %#x", got)
+	}
+}
+
+func TestCharmapZero(t *testing.T) {
+	var cm Charmap
+
+	// The zero value is empty, and can be extended
+	if got := cm.Decode([]byte{'A'}); got != string(DecodeMissing) {
+		t.Errorf("Decode mismatch, got %q", got)
+	}
+	if err := cm.Load(strings.NewReader("0x41: 0x41\n0x0: 0x20")); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	if got := cm.Decode([]byte{'A', ' ', 'B'}); got != "A\x00"+string(DecodeMissing) {
+		t.Errorf("Decode mismatch, got %q", got)
+	}
+	if got := cm.Convert("AB", ConvertReplace, '?'); !bytes.Equal(got, []byte("A?")) {
+		t.Errorf("Convert mismatch, got %q", got)
+	}
+}
+
+func TestCharmapLoadErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"2191", "line 1: missing charmap value"},
+		{"\n\nXYZ: 0x20", "line 3: invalid code point \"XYZ\""},
+		{"2191: 0x100", "line 1: invalid charmap value \"100\""},
+		{"2191: zz", "line 1: invalid charmap value \"zz\""},
+		{"110000: 0x20", "line 1: invalid code point \"110000\""},
+		{"2191 ab", "line 1: unexpected text \"b\" after charmap value"},
+		{"2191 \\", "line 1: character '\\\\' is not in the character map"},
+	}
+
+	for _, tc := range tests {
+		err := NewCharmap().Load(strings.NewReader(tc.input))
+		if err == nil || err.Error() != tc.err {
+			t.Errorf("Loading %q, expected error %q, got %v", tc.input, tc.err, err)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package util
+
+// GlyphWidth and GlyphHeight are the dimensions in pixels of each character
+// cell on the MFD
+const (
+	GlyphWidth  = 5
+	GlyphHeight = 8
+)
+
+// Glyph returns the bitmap for the byte in the code page of the MFD display.
+// Each element of the result is one row of the glyph, from top to bottom,
+// with the leftmost pixel in bit 4 of the row.
+func Glyph(c byte) [GlyphHeight]byte {
+	return mfdFont[c]
+}
+
+// Bitmap font for the X52 Pro MFD character map
+
+// The glyphs below are drawn to resemble the characters listed in charmap.go,
+// they are not a copy of the glyphs in the display controller. Bytes with no
+// entry in the character map are drawn as a checkerboard, so that they are
+// easy to spot in rendered output.
+var mfdFont = [256][GlyphHeight]byte{
+	0x00: {0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A}, // Unknown glyph
+	0x01: {0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A}, // Unknown glyph
+	0x02: {0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A}, // Unknown glyph
+	0x03: {0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A}, // Unknown glyph
+	0x04: {0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A}, // Unknown glyph
+	0x05: {0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A}, // Unknown glyph
+	0x06: {0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A}, // Unknown glyph
+	0x07: {0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A}, // Unknown glyph
+	0x08: {0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A}, // Unknown glyph
+	0x09: {0x00, 0x00, 0x00, 0x07, 0x04, 0x04, 0x04, 0x04}, // BOX DRAWINGS LIGHT DOWN AND RIGHT
+	0x0A: {0x00, 0x00, 0x00, 0x1C, 0x04, 0x04, 0x04, 0x04}, // BOX DRAWINGS LIGHT DOWN AND LEFT
+	0x0B: {0x04, 0x04, 0x04, 0x07, 0x00, 0x00, 0x00, 0x00}, // BOX DRAWINGS LIGHT UP AND RIGHT
+	0x0C: {0x04, 0x04, 0x04, 0x1C, 0x00, 0x00, 0x00, 0x00}, // BOX DRAWINGS LIGHT UP AND LEFT
+	0x0D: {0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x00}, // MIDDLE DOT
+	0x0E: {0x0E, 0x1D, 0x1B, 0x1D, 0x1B, 0x0E, 0x00, 0x00}, // REGISTERED SIGN
+	0x0F: {0x0E, 0x11, 0x17, 0x15, 0x17, 0x11, 0x0E, 0x00}, // COPYRIGHT SIGN
+	0x10: {0x1D, 0x0B, 0x09, 0x00, 0x00, 0x00, 0x00, 0x00}, // TRADE MARK SIGN
+	0x11: {0x04, 0x0E, 0x04, 0x04, 0x04, 0x04, 0x00, 0x00}, // DAGGER
+	0x12: {0x0E, 0x10, 0x0E, 0x11, 0x0E, 0x01, 0x0E, 0x00}, // SECTION SIGN
+	0x13: {0x0F, 0x1D, 0x1D, 0x0D, 0x05, 0x05, 0x05, 0x00}, // PILCROW SIGN
+	0x14: {0x1F, 0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x00}, // GREEK CAPITAL LETTER GAMMA
+	0x15: {0x04, 0x04, 0x0A, 0x0A, 0x11, 0x11, 0x1F, 0x00}, // GREEK CAPITAL LETTER DELTA
+	0x16: {0x0E, 0x11, 0x11, 0x1F, 0x11, 0x11, 0x0E, 0x00}, // GREEK CAPITAL LETTER THETA
+	0x17: {0x04, 0x0A, 0x0A, 0x11, 0x11, 0x11, 0x11, 0x00}, // GREEK CAPITAL LETTER LAMDA
+	0x18: {0x1F, 0x00, 0x00, 0x0E, 0x00, 0x00, 0x1F, 0x00}, // GREEK CAPITAL LETTER XI
+	0x19: {0x1F, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x00}, // GREEK CAPITAL LETTER PI
+	0x1A: {0x1F, 0x10, 0x08, 0x04, 0x08, 0x10, 0x1F, 0x00}, // GREEK CAPITAL LETTER SIGMA
+	0x1B: {0x11, 0x11, 0x0A, 0x04, 0x04, 0x04, 0x04, 0x00}, // GREEK UPSILON WITH HOOK SYMBOL
+	0x1C: {0x04, 0x0E, 0x15, 0x15, 0x15, 0x0E, 0x04, 0x00}, // GREEK CAPITAL LETTER PHI
+	0x1D: {0x15, 0x15, 0x15, 0x0E, 0x04, 0x04, 0x04, 0x00}, // GREEK CAPITAL LETTER PSI
+	0x1E: {0x0E, 0x11, 0x11, 0x11, 0x0A, 0x0A, 0x1B, 0x00}, // GREEK CAPITAL LETTER OMEGA
+	0x1F: {0x00, 0x00, 0x09, 0x15, 0x12, 0x12, 0x0D, 0x00}, // GREEK SMALL LETTER ALPHA
+	0x20: {0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, // SPACE
+	0x21: {0x04, 0x04, 0x04, 0x04, 0x00, 0x00, 0x04, 0x00}, // EXCLAMATION MARK
+	0x22: {0x0A, 0x0A, 0x0A, 0x00, 0x00, 0x00, 0x00, 0x00}, // QUOTATION MARK
+	0x23: {0x0A, 0x0A, 0x1F, 0x0A, 0x1F, 0x0A, 0x0A, 0x00}, // NUMBER SIGN
+	0x24: {0x04, 0x0F, 0x14, 0x0E, 0x05, 0x1E, 0x04, 0x00}, // DOLLAR SIGN
+	0x25: {0x18, 0x19, 0x02, 0x04, 0x08, 0x13, 0x03, 0x00}, // PERCENT SIGN
+	0x26: {0x0C, 0x12, 0x14, 0x08, 0x15, 0x12, 0x0D, 0x00}, // AMPERSAND
+	0x27: {0x0C, 0x04, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00}, // APOSTROPHE
+	0x28: {0x02, 0x04, 0x08, 0x08, 0x08, 0x04, 0x02, 0x00}, // LEFT PARENTHESIS
+	0x29: {0x08, 0x04, 0x02, 0x02, 0x02, 0x04, 0x08, 0x00}, // RIGHT PARENTHESIS
+	0x2A: {0x00, 0x04, 0x15, 0x0E, 0x15, 0x04, 0x00, 0x00}, // ASTERISK
+	0x2B: {0x00, 0x04, 0x04, 0x1F, 0x04, 0x04, 0x00, 0x00}, // PLUS SIGN
+	0x2C: {0x00, 0x00, 0x00, 0x00, 0x00, 0x0C, 0x04, 0x08}, // COMMA
+	0x2D: {0x00, 0x00, 0x00, 0x1F, 0x00, 0x00, 0x00, 0x00}, // HYPHEN-MINUS
+	0x2E: {0x00, 0x00, 0x00, 0x00, 0x00, 0x0C, 0x0C, 0x00}, // FULL STOP
+	0x2F: {0x00, 0x01, 0x02, 0x04, 0x08, 0x10, 0x00, 0x00}, // SOLIDUS
+	0x30: {0x0E, 0x11, 0x13, 0x15, 0x19, 0x11, 0x0E, 0x00}, // DIGIT ZERO
+	0x31: {0x04, 0x0C, 0x04, 0x04, 0x04, 0x04, 0x0E, 0x00}, // DIGIT ONE
+	0x32: {0x0E, 0x11, 0x01, 0x02, 0x04, 0x08, 0x1F, 0x00}, // DIGIT TWO
+	0x33: {0x1F, 0x02, 0x04, 0x02, 0x01, 0x11, 0x0E, 0x00}, // DIGIT THREE
+	0x34: {0x02, 0x06, 0x0A, 0x12, 0x1F, 0x02, 0x02, 0x00}, // DIGIT FOUR
+	0x35: {0x1F, 0x10, 0x1E, 0x01, 0x01, 0x11, 0x0E, 0x00}, // DIGIT FIVE
+	0x36: {0x06, 0x08, 0x10, 0x1E, 0x11, 0x11, 0x0E, 0x00}, // DIGIT SIX
+	0x37: {0x1F, 0x01, 0x02, 0x04, 0x08, 0x08, 0x08, 0x00}, // DIGIT SEVEN
+	0x38: {0x0E, 0x11, 0x11, 0x0E, 0x11, 0x11, 0x0E, 0x00}, // DIGIT EIGHT
+	0x39: {0x0E, 0x11, 0x11, 0x0F, 0x01, 0x02, 0x0C, 0x00}, // DIGIT NINE
+	0x3A: {0x00, 0x0C, 0x0C, 0x00, 0x0C, 0x0C, 0x00, 0x00}, // COLON
+	0x3B: {0x00, 0x0C, 0x0C, 0x00, 0x0C, 0x04, 0x08, 0x00}, // SEMICOLON
+	0x3C: {0x02, 0x04, 0x08, 0x10, 0x08, 0x04, 0x02, 0x00}, // LESS-THAN SIGN
+	0x3D: {0x00, 0x00, 0x1F, 0x00, 0x1F, 0x00, 0x00, 0x00}, // EQUALS SIGN
+	0x3E: {0x08, 0x04, 0x02, 0x01, 0x02, 0x04, 0x08, 0x00}, // GREATER-THAN SIGN
+	0x3F: {0x0E, 0x11, 0x01, 0x02, 0x04, 0x00, 0x04, 0x00}, // QUESTION MARK
+	0x40: {0x0E, 0x11, 0x01, 0x0D, 0x15, 0x15, 0x0E, 0x00}, // COMMERCIAL AT
+	0x41: {0x0E, 0x11, 0x11, 0x11, 0x1F, 0x11, 0x11, 0x00}, // LATIN CAPITAL LETTER A
+	0x42: {0x1E, 0x11, 0x11, 0x1E, 0x11, 0x11, 0x1E, 0x00}, // LATIN CAPITAL LETTER B
+	0x43: {0x0E, 0x11, 0x10, 0x10, 0x10, 0x11, 0x0E, 0x00}, // LATIN CAPITAL LETTER C
+	0x44: {0x1C, 0x12, 0x11, 0x11, 0x11, 0x12, 0x1C, 0x00}, // LATIN CAPITAL LETTER D
+	0x45: {0x1F, 0x10, 0x10, 0x1E, 0x10, 0x10, 0x1F, 0x00}, // LATIN CAPITAL LETTER E
+	0x46: {0x1F, 0x10, 0x10, 0x1E, 0x10, 0x10, 0x10, 0x00}, // LATIN CAPITAL LETTER F
+	0x47: {0x0E, 0x11, 0x10, 0x17, 0x11, 0x11, 0x0F, 0x00}, // LATIN CAPITAL LETTER G
+	0x48: {0x11, 0x11, 0x11, 0x1F, 0x11, 0x11, 0x11, 0x00}, // LATIN CAPITAL LETTER H
+	0x49: {0x0E, 0x04, 0x04, 0x04, 0x04, 0x04, 0x0E, 0x00}, // LATIN CAPITAL LETTER I
+	0x4A: {0x07, 0x02, 0x02, 0x02, 0x02, 0x12, 0x0C, 0x00}, // LATIN CAPITAL LETTER J
+	0x4B: {0x11, 0x12, 0x14, 0x18, 0x14, 0x12, 0x11, 0x00}, // LATIN CAPITAL LETTER K
+	0x4C: {0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x1F, 0x00}, // LATIN CAPITAL LETTER L
+	0x4D: {0x11, 0x1B, 0x15, 0x15, 0x11, 0x11, 0x11, 0x00}, // LATIN CAPITAL LETTER M
+	0x4E: {0x11, 0x11, 0x19, 0x15, 0x13, 0x11, 0x11, 0x00}, // LATIN CAPITAL LETTER N
+	0x4F: {0x0E, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0E, 0x00}, // LATIN CAPITAL LETTER O
+	0x50: {0x1E, 0x11, 0x11, 0x1E, 0x10, 0x10, 0x10, 0x00}, // LATIN CAPITAL LETTER P
+	0x51: {0x0E, 0x11, 0x11, 0x11, 0x15, 0x12, 0x0D, 0x00}, // LATIN CAPITAL LETTER Q
+	0x52: {0x1E, 0x11, 0x11, 0x1E, 0x14, 0x12, 0x11, 0x00}, // LATIN CAPITAL LETTER R
+	0x53: {0x0F, 0x10, 0x10, 0x0E, 0x01, 0x01, 0x1E, 0x00}, // LATIN CAPITAL LETTER S
+	0x54: {0x1F, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x00}, // LATIN CAPITAL LETTER T
+	0x55: {0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0E, 0x00}, // LATIN CAPITAL LETTER U
+	0x56: {0x11, 0x11, 0x11, 0x11, 0x11, 0x0A, 0x04, 0x00}, // LATIN CAPITAL LETTER V
+	0x57: {0x11, 0x11, 0x11, 0x15, 0x15, 0x15, 0x0A, 0x00}, // LATIN CAPITAL LETTER W
+	0x58: {0x11, 0x11, 0x0A, 0x04, 0x0A, 0x11, 0x11, 0x00}, // LATIN CAPITAL LETTER X
+	0x59: {0x11, 0x11, 0x11, 0x0A, 0x04, 0x04, 0x04, 0x00}, // LATIN CAPITAL LETTER Y
+	0x5A: {0x1F, 0x01, 0x02, 0x04, 0x08, 0x10, 0x1F, 0x00}, // LATIN CAPITAL LETTER Z
+	0x5B: {0x0E, 0x08, 0x08, 0x08, 0x08, 0x08, 0x0E, 0x00}, // LEFT SQUARE BRACKET
+	0x5C: {0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A}, // Unknown glyph
+	0x5D: {0x0E, 0x02, 0x02, 0x02, 0x02, 0x02, 0x0E, 0x00}, // RIGHT SQUARE BRACKET
+	0x5E: {0x04, 0x0A, 0x11, 0x00, 0x00, 0x00, 0x00, 0x00}, // CIRCUMFLEX ACCENT
+	0x5F: {0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x1F, 0x00}, // LOW LINE
+	0x60: {0x08, 0x04, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00}, // GRAVE ACCENT
+	0x61: {0x00, 0x00, 0x0E, 0x01, 0x0F, 0x11, 0x0F, 0x00}, // LATIN SMALL LETTER A
+	0x62: {0x10, 0x10, 0x16, 0x19, 0x11, 0x11, 0x1E, 0x00}, // LATIN SMALL LETTER B
+	0x63: {0x00, 0x00, 0x0E, 0x10, 0x10, 0x11, 0x0E, 0x00}, // LATIN SMALL LETTER C
+	0x64: {0x01, 0x01, 0x0D, 0x13, 0x11, 0x11, 0x0F, 0x00}, // LATIN SMALL LETTER D
+	0x65: {0x00, 0x00, 0x0E, 0x11, 0x1F, 0x10, 0x0E, 0x00}, // LATIN SMALL LETTER E
+	0x66: {0x06, 0x09, 0x08, 0x1C, 0x08, 0x08, 0x08, 0x00}, // LATIN SMALL LETTER F
+	0x67: {0x00, 0x00, 0x0F, 0x11, 0x11, 0x0F, 0x01, 0x0E}, // LATIN SMALL LETTER G
+	0x68: {0x10, 0x10, 0x16, 0x19, 0x11, 0x11, 0x11, 0x00}, // LATIN SMALL LETTER H
+	0x69: {0x04, 0x00, 0x0C, 0x04, 0x04, 0x04, 0x0E, 0x00}, // LATIN SMALL LETTER I
+	0x6A: {0x02, 0x00, 0x06, 0x02, 0x02, 0x02, 0x12, 0x0C}, // LATIN SMALL LETTER J
+	0x6B: {0x10, 0x10, 0x12, 0x14, 0x18, 0x14, 0x12, 0x00}, // LATIN SMALL LETTER K
+	0x6C: {0x0C, 0x04, 0x04, 0x04, 0x04, 0x04, 0x0E, 0x00}, // LATIN SMALL LETTER L
+	0x6D: {0x00, 0x00, 0x1A, 0x15, 0x15, 0x11, 0x11, 0x00}, // LATIN SMALL LETTER M
+	0x6E: {0x00, 0x00, 0x16, 0x19, 0x11, 0x11, 0x11, 0x00}, // LATIN SMALL LETTER N
+	0x6F: {0x00, 0x00, 0x0E, 0x11, 0x11, 0x11, 0x0E, 0x00}, // LATIN SMALL LETTER O
+	0x70: {0x00, 0x00, 0x1E, 0x11, 0x11, 0x1E, 0x10, 0x10}, // LATIN SMALL LETTER P
+	0x71: {0x00, 0x00, 0x0D, 0x13, 0x11, 0x0F, 0x01, 0x01}, // LATIN SMALL LETTER Q
+	0x72: {0x00, 0x00, 0x16, 0x19, 0x10, 0x10, 0x10, 0x00}, // LATIN SMALL LETTER R
+	0x73: {0x00, 0x00, 0x0E, 0x10, 0x0E, 0x01, 0x1E, 0x00}, // LATIN SMALL LETTER S
+	0x74: {0x08, 0x08, 0x1C, 0x08, 0x08, 0x09, 0x06, 0x00}, // LATIN SMALL LETTER T
+	0x75: {0x00, 0x00, 0x11, 0x11, 0x11, 0x13, 0x0D, 0x00}, // LATIN SMALL LETTER U
+	0x76: {0x00, 0x00, 0x11, 0x11, 0x11, 0x0A, 0x04, 0x00}, // LATIN SMALL LETTER V
+	0x77: {0x00, 0x00, 0x11, 0x11, 0x15, 0x15, 0x0A, 0x00}, // LATIN SMALL LETTER W
+	0x78: {0x00, 0x00, 0x11, 0x0A, 0x04, 0x0A, 0x11, 0x00}, // LATIN SMALL LETTER X
+	0x79: {0x00, 0x00, 0x11, 0x11, 0x11, 0x0F, 0x01, 0x0E}, // LATIN SMALL LETTER Y
+	0x7A: {0x00, 0x00, 0x1F, 0x02, 0x04, 0x08, 0x1F, 0x00}, // LATIN SMALL LETTER Z
+	0x7B: {0x02, 0x04, 0x04, 0x08, 0x04, 0x04, 0x02, 0x00}, // LEFT CURLY BRACKET
+	0x7C: {0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x00}, // VERTICAL LINE
+	0x7D: {0x08, 0x04, 0x04, 0x02, 0x04, 0x04, 0x08, 0x00}, // RIGHT CURLY BRACKET
+	0x7E: {0x00, 0x04, 0x02, 0x1F, 0x02, 0x04, 0x00, 0x00}, // RIGHTWARDS ARROW
+	0x7F: {0x00, 0x04, 0x08, 0x1F, 0x08, 0x04, 0x00, 0x00}, // LEFTWARDS ARROW
+	0x80: {0x0E, 0x11, 0x10, 0x10, 0x11, 0x0E, 0x04, 0x0C}, // LATIN CAPITAL LETTER C WITH CEDILLA
+	0x81: {0x0A, 0x00, 0x11, 0x11, 0x11, 0x13, 0x0D, 0x00}, // LATIN SMALL LETTER U WITH DIAERESIS
+	0x82: {0x02, 0x04, 0x0E, 0x11, 0x1F, 0x10, 0x0E, 0x00}, // LATIN SMALL LETTER E WITH ACUTE
+	0x83: {0x04, 0x0A, 0x0E, 0x01, 0x0F, 0x11, 0x0F, 0x00}, // LATIN SMALL LETTER A WITH CIRCUMFLEX
+	0x84: {0x0A, 0x00, 0x0E, 0x01, 0x0F, 0x11, 0x0F, 0x00}, // LATIN SMALL LETTER A WITH DIAERESIS
+	0x85: {0x08, 0x04, 0x0E, 0x01, 0x0F, 0x11, 0x0F, 0x00}, // LATIN SMALL LETTER A WITH GRAVE
+	0x86: {0x04, 0x00, 0x0E, 0x01, 0x0F, 0x11, 0x0F, 0x00}, // LATIN SMALL LETTER A WITH DOT ABOVE
+	0x87: {0x00, 0x00, 0x0E, 0x10, 0x11, 0x0E, 0x04, 0x0C}, // LATIN SMALL LETTER C WITH CEDILLA
+	0x88: {0x04, 0x0A, 0x0E, 0x11, 0x1F, 0x10, 0x0E, 0x00}, // LATIN SMALL LETTER E WITH CIRCUMFLEX
+	0x89: {0x0A, 0x00, 0x0E, 0x11, 0x1F, 0x10, 0x0E, 0x00}, // LATIN SMALL LETTER E WITH DIAERESIS
+	0x8A: {0x08, 0x04, 0x0E, 0x11, 0x1F, 0x10, 0x0E, 0x00}, // LATIN SMALL LETTER E WITH GRAVE
+	0x8B: {0x0A, 0x00, 0x0C, 0x04, 0x04, 0x04, 0x0E, 0x00}, // LATIN SMALL LETTER I WITH DIAERESIS
+	0x8C: {0x04, 0x0A, 0x0C, 0x04, 0x04, 0x04, 0x0E, 0x00}, // LATIN SMALL LETTER I WITH CIRCUMFLEX
+	0x8D: {0x08, 0x04, 0x0C, 0x04, 0x04, 0x04, 0x0E, 0x00}, // LATIN SMALL LETTER I WITH GRAVE
+	0x8E: {0x0A, 0x00, 0x0E, 0x11, 0x1F, 0x11, 0x11, 0x00}, // LATIN CAPITAL LETTER A WITH DIAERESIS
+	0x8F: {0x04, 0x0A, 0x0E, 0x11, 0x1F, 0x11, 0x11, 0x00}, // LATIN CAPITAL LETTER A WITH CIRCUMFLEX
+	0x90: {0x02, 0x04, 0x1F, 0x10, 0x1E, 0x10, 0x1F, 0x00}, // LATIN CAPITAL LETTER E WITH ACUTE
+	0x91: {0x00, 0x00, 0x1A, 0x05, 0x0F, 0x14, 0x0B, 0x00}, // LATIN SMALL LETTER AE
+	0x92: {0x0F, 0x14, 0x14, 0x1E, 0x14, 0x14, 0x17, 0x00}, // LATIN CAPITAL LETTER AE
+	0x93: {0x04, 0x0A, 0x0E, 0x11, 0x11, 0x11, 0x0E, 0x00}, // LATIN SMALL LETTER O WITH CIRCUMFLEX
+	0x94: {0x0A, 0x00, 0x0E, 0x11, 0x11, 0x11, 0x0E, 0x00}, // LATIN SMALL LETTER O WITH DIAERESIS
+	0x95: {0x08, 0x04, 0x0E, 0x11, 0x11, 0x11, 0x0E, 0x00}, // LATIN SMALL LETTER O WITH GRAVE
+	0x96: {0x04, 0x0A, 0x11, 0x11, 0x11, 0x13, 0x0D, 0x00}, // LATIN SMALL LETTER U WITH CIRCUMFLEX
+	0x97: {0x08, 0x04, 0x11, 0x11, 0x11, 0x13, 0x0D, 0x00}, // LATIN SMALL LETTER U WITH GRAVE
+	0x98: {0x0A, 0x00, 0x11, 0x11, 0x11, 0x0F, 0x01, 0x0E}, // LATIN SMALL LETTER Y WITH DIAERESIS
+	0x99: {0x0A, 0x0E, 0x11, 0x11, 0x11, 0x11, 0x0E, 0x00}, // LATIN CAPITAL LETTER O WITH DIAERESIS
+	0x9A: {0x0A, 0x00, 0x11, 0x11, 0x11, 0x11, 0x0E, 0x00}, // LATIN CAPITAL LETTER U WITH DIAERESIS
+	0x9B: {0x0D, 0x16, 0x16, 0x19, 0x11, 0x11, 0x11, 0x00}, // LATIN SMALL LETTER N WITH TILDE
+	0x9C: {0x0D, 0x16, 0x11, 0x19, 0x15, 0x13, 0x11, 0x00}, // LATIN CAPITAL LETTER N WITH TILDE
+	0x9D: {0x0E, 0x01, 0x0F, 0x11, 0x0F, 0x00, 0x1F, 0x00}, // FEMININE ORDINAL INDICATOR
+	0x9E: {0x0E, 0x11, 0x11, 0x11, 0x0E, 0x00, 0x1F, 0x00}, // MASCULINE ORDINAL INDICATOR
+	0x9F: {0x04, 0x00, 0x04, 0x08, 0x10, 0x11, 0x0E, 0x00}, // INVERTED QUESTION MARK
+	0xA0: {0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, // NO-BREAK SPACE
+	0xA1: {0x00, 0x00, 0x00, 0x00, 0x1C, 0x14, 0x1C, 0x00}, // HALFWIDTH IDEOGRAPHIC FULL STOP
+	0xA2: {0x07, 0x04, 0x04, 0x04, 0x00, 0x00, 0x00, 0x00}, // HALFWIDTH LEFT CORNER BRACKET
+	0xA3: {0x00, 0x00, 0x00, 0x04, 0x04, 0x04, 0x1C, 0x00}, // HALFWIDTH RIGHT CORNER BRACKET
+	0xA4: {0x00, 0x00, 0x00, 0x00, 0x10, 0x08, 0x04, 0x00}, // HALFWIDTH IDEOGRAPHIC COMMA
+	0xA5: {0x00, 0x00, 0x00, 0x0C, 0x0C, 0x00, 0x00, 0x00}, // HALFWIDTH KATAKANA MIDDLE DOT
+	0xA6: {0x00, 0x1F, 0x01, 0x1F, 0x01, 0x02, 0x04, 0x00}, // HALFWIDTH KATAKANA LETTER WO
+	0xA7: {0x00, 0x00, 0x1F, 0x01, 0x06, 0x04, 0x08, 0x00}, // HALFWIDTH KATAKANA LETTER SMALL A
+	0xA8: {0x00, 0x00, 0x02, 0x04, 0x0C, 0x14, 0x04, 0x00}, // HALFWIDTH KATAKANA LETTER SMALL I
+	0xA9: {0x00, 0x00, 0x04, 0x1F, 0x11, 0x01, 0x06, 0x00}, // HALFWIDTH KATAKANA LETTER SMALL U
+	0xAA: {0x00, 0x00, 0x00, 0x1F, 0x04, 0x04, 0x1F, 0x00}, // HALFWIDTH KATAKANA LETTER SMALL E
+	0xAB: {0x00, 0x00, 0x02, 0x1F, 0x06, 0x0A, 0x12, 0x00}, // HALFWIDTH KATAKANA LETTER SMALL O
+	0xAC: {0x00, 0x00, 0x08, 0x1F, 0x09, 0x0A, 0x08, 0x00}, // HALFWIDTH KATAKANA LETTER SMALL YA
+	0xAD: {0x00, 0x00, 0x00, 0x0E, 0x02, 0x02, 0x1F, 0x00}, // HALFWIDTH KATAKANA LETTER SMALL YU
+	0xAE: {0x00, 0x00, 0x1E, 0x02, 0x1E, 0x02, 0x1E, 0x00}, // HALFWIDTH KATAKANA LETTER SMALL YO
+	0xAF: {0x00, 0x00, 0x00, 0x15, 0x15, 0x01, 0x06, 0x00}, // HALFWIDTH KATAKANA LETTER SMALL TU
+	0xB0: {0x00, 0x00, 0x00, 0x1F, 0x00, 0x00, 0x00, 0x00}, // HALFWIDTH KATAKANA-HIRAGANA PROLONGED SOUND MARK
+	0xB1: {0x1F, 0x01, 0x05, 0x06, 0x04, 0x04, 0x08, 0x00}, // HALFWIDTH KATAKANA LETTER A
+	0xB2: {0x01, 0x02, 0x04, 0x0C, 0x14, 0x04, 0x04, 0x00}, // HALFWIDTH KATAKANA LETTER I
+	0xB3: {0x04, 0x1F, 0x11, 0x11, 0x01, 0x02, 0x04, 0x00}, // HALFWIDTH KATAKANA LETTER U
+	0xB4: {0x00, 0x1F, 0x04, 0x04, 0x04, 0x04, 0x1F, 0x00}, // HALFWIDTH KATAKANA LETTER E
+	0xB5: {0x02, 0x1F, 0x02, 0x06, 0x0A, 0x12, 0x02, 0x00}, // HALFWIDTH KATAKANA LETTER O
+	0xB6: {0x08, 0x1F, 0x09, 0x09, 0x09, 0x09, 0x12, 0x00}, // HALFWIDTH KATAKANA LETTER KA
+	0xB7: {0x04, 0x1F, 0x04, 0x1F, 0x04, 0x04, 0x04, 0x00}, // HALFWIDTH KATAKANA LETTER KI
+	0xB8: {0x00, 0x0F, 0x09, 0x11, 0x01, 0x02, 0x0C, 0x00}, // HALFWIDTH KATAKANA LETTER KU
+	0xB9: {0x08, 0x0F, 0x12, 0x02, 0x02, 0x02, 0x04, 0x00}, // HALFWIDTH KATAKANA LETTER KE
+	0xBA: {0x00, 0x1F, 0x01, 0x01, 0x01, 0x01, 0x1F, 0x00}, // HALFWIDTH KATAKANA LETTER KO
+	0xBB: {0x0A, 0x1F, 0x0A, 0x0A, 0x02, 0x04, 0x08, 0x00}, // HALFWIDTH KATAKANA LETTER SA
+	0xBC: {0x00, 0x18, 0x01, 0x19, 0x01, 0x02, 0x1C, 0x00}, // HALFWIDTH KATAKANA LETTER SI
+	0xBD: {0x00, 0x1F, 0x01, 0x02, 0x04, 0x0A, 0x11, 0x00}, // HALFWIDTH KATAKANA LETTER SU
+	0xBE: {0x08, 0x1F, 0x09, 0x0A, 0x08, 0x08, 0x07, 0x00}, // HALFWIDTH KATAKANA LETTER SE
+	0xBF: {0x00, 0x11, 0x11, 0x09, 0x01, 0x02, 0x0C, 0x00}, // HALFWIDTH KATAKANA LETTER SO
+	0xC0: {0x00, 0x0F, 0x09, 0x15, 0x03, 0x02, 0x0C, 0x00}, // HALFWIDTH KATAKANA LETTER TA
+	0xC1: {0x02, 0x1C, 0x04, 0x1F, 0x04, 0x04, 0x08, 0x00}, // HALFWIDTH KATAKANA LETTER TI
+	0xC2: {0x00, 0x15, 0x15, 0x15, 0x01, 0x02, 0x04, 0x00}, // HALFWIDTH KATAKANA LETTER TU
+	0xC3: {0x0E, 0x00, 0x1F, 0x04, 0x04, 0x04, 0x08, 0x00}, // HALFWIDTH KATAKANA LETTER TE
+	0xC4: {0x08, 0x08, 0x08, 0x0C, 0x0A, 0x08, 0x08, 0x00}, // HALFWIDTH KATAKANA LETTER TO
+	0xC5: {0x04, 0x04, 0x1F, 0x04, 0x04, 0x08, 0x10, 0x00}, // HALFWIDTH KATAKANA LETTER NA
+	0xC6: {0x00, 0x0E, 0x00, 0x00, 0x00, 0x00, 0x1F, 0x00}, // HALFWIDTH KATAKANA LETTER NI
+	0xC7: {0x00, 0x1F, 0x01, 0x0A, 0x04, 0x0A, 0x10, 0x00}, // HALFWIDTH KATAKANA LETTER NU
+	0xC8: {0x04, 0x1F, 0x02, 0x04, 0x0E, 0x15, 0x04, 0x00}, // HALFWIDTH KATAKANA LETTER NE
+	0xC9: {0x02, 0x02, 0x02, 0x02, 0x02, 0x04, 0x08, 0x00}, // HALFWIDTH KATAKANA LETTER NO
+	0xCA: {0x00, 0x04, 0x02, 0x11, 0x11, 0x11, 0x11, 0x00}, // HALFWIDTH KATAKANA LETTER HA
+	0xCB: {0x10, 0x10, 0x1F, 0x10, 0x10, 0x10, 0x0F, 0x00}, // HALFWIDTH KATAKANA LETTER HI
+	0xCC: {0x00, 0x1F, 0x01, 0x01, 0x01, 0x02, 0x0C, 0x00}, // HALFWIDTH KATAKANA LETTER HU
+	0xCD: {0x00, 0x08, 0x14, 0x02, 0x01, 0x01, 0x00, 0x00}, // HALFWIDTH KATAKANA LETTER HE
+	0xCE: {0x04, 0x1F, 0x04, 0x04, 0x15, 0x15, 0x04, 0x00}, // HALFWIDTH KATAKANA LETTER HO
+	0xCF: {0x00, 0x1F, 0x01, 0x01, 0x0A, 0x04, 0x02, 0x00}, // HALFWIDTH KATAKANA LETTER MA
+	0xD0: {0x00, 0x0E, 0x00, 0x0E, 0x00, 0x0E, 0x01, 0x00}, // HALFWIDTH KATAKANA LETTER MI
+	0xD1: {0x00, 0x04, 0x08, 0x10, 0x11, 0x1F, 0x01, 0x00}, // HALFWIDTH KATAKANA LETTER MU
+	0xD2: {0x00, 0x01, 0x01, 0x0A, 0x04, 0x0A, 0x10, 0x00}, // HALFWIDTH KATAKANA LETTER ME
+	0xD3: {0x00, 0x1F, 0x08, 0x1F, 0x08, 0x08, 0x07, 0x00}, // HALFWIDTH KATAKANA LETTER MO
+	0xD4: {0x08, 0x08, 0x1F, 0x09, 0x0A, 0x08, 0x08, 0x00}, // HALFWIDTH KATAKANA LETTER YA
+	0xD5: {0x00, 0x0E, 0x02, 0x02, 0x02, 0x02, 0x1F, 0x00}, // HALFWIDTH KATAKANA LETTER YU
+	0xD6: {0x00, 0x1F, 0x01, 0x1F, 0x01, 0x01, 0x1F, 0x00}, // HALFWIDTH KATAKANA LETTER YO
+	0xD7: {0x0E, 0x00, 0x1F, 0x01, 0x01, 0x02, 0x04, 0x00}, // HALFWIDTH KATAKANA LETTER RA
+	0xD8: {0x12, 0x12, 0x12, 0x12, 0x02, 0x04, 0x08, 0x00}, // HALFWIDTH KATAKANA LETTER RI
+	0xD9: {0x00, 0x04, 0x14, 0x14, 0x15, 0x15, 0x16, 0x00}, // HALFWIDTH KATAKANA LETTER RU
+	0xDA: {0x00, 0x10, 0x10, 0x11, 0x12, 0x14, 0x18, 0x00}, // HALFWIDTH KATAKANA LETTER RE
+	0xDB: {0x00, 0x1F, 0x11, 0x11, 0x11, 0x11, 0x1F, 0x00}, // HALFWIDTH KATAKANA LETTER RO
+	0xDC: {0x00, 0x1F, 0x11, 0x11, 0x01, 0x02, 0x04, 0x00}, // HALFWIDTH KATAKANA LETTER WA
+	0xDD: {0x00, 0x18, 0x00, 0x01, 0x01, 0x02, 0x1C, 0x00}, // HALFWIDTH KATAKANA LETTER N
+	0xDE: {0x04, 0x12, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00}, // HALFWIDTH KATAKANA VOICED SOUND MARK
+	0xDF: {0x1C, 0x14, 0x1C, 0x00, 0x00, 0x00, 0x00, 0x00}, // HALFWIDTH KATAKANA SEMI-VOICED SOUND MARK
+	0xE0: {0x02, 0x04, 0x0E, 0x01, 0x0F, 0x11, 0x0F, 0x00}, // LATIN SMALL LETTER A WITH ACUTE
+	0xE1: {0x02, 0x04, 0x0C, 0x04, 0x04, 0x04, 0x0E, 0x00}, // LATIN SMALL LETTER I WITH ACUTE
+	0xE2: {0x02, 0x04, 0x0E, 0x11, 0x11, 0x11, 0x0E, 0x00}, // LATIN SMALL LETTER O WITH ACUTE
+	0xE3: {0x02, 0x04, 0x11, 0x11, 0x11, 0x13, 0x0D, 0x00}, // LATIN SMALL LETTER U WITH ACUTE
+	0xE4: {0x04, 0x0E, 0x14, 0x14, 0x15, 0x0E, 0x04, 0x00}, // CENT SIGN
+	0xE5: {0x06, 0x09, 0x08, 0x1C, 0x08, 0x09, 0x16, 0x00}, // POUND SIGN
+	0xE6: {0x11, 0x0A, 0x1F, 0x04, 0x1F, 0x04, 0x04, 0x00}, // YEN SIGN
+	0xE7: {0x1C, 0x14, 0x1C, 0x12, 0x17, 0x12, 0x13, 0x00}, // PESETA SIGN
+	0xE8: {0x03, 0x04, 0x0E, 0x04, 0x04, 0x04, 0x18, 0x00}, // LATIN SMALL LETTER F WITH HOOK
+	0xE9: {0x04, 0x00, 0x04, 0x04, 0x04, 0x04, 0x04, 0x00}, // INVERTED EXCLAMATION MARK
+	0xEA: {0x0D, 0x16, 0x0E, 0x11, 0x1F, 0x11, 0x11, 0x00}, // LATIN CAPITAL LETTER A WITH TILDE
+	0xEB: {0x0D, 0x16, 0x0E, 0x01, 0x0F, 0x11, 0x0F, 0x00}, // LATIN SMALL LETTER A WITH TILDE
+	0xEC: {0x0D, 0x16, 0x0E, 0x11, 0x11, 0x11, 0x0E, 0x00}, // LATIN CAPITAL LETTER O WITH TILDE
+	0xED: {0x0D, 0x16, 0x00, 0x0E, 0x11, 0x11, 0x0E, 0x00}, // LATIN SMALL LETTER O WITH TILDE
+	0xEE: {0x0E, 0x13, 0x15, 0x15, 0x15, 0x19, 0x0E, 0x00}, // LATIN CAPITAL LETTER O WITH STROKE
+	0xEF: {0x00, 0x00, 0x0E, 0x13, 0x15, 0x19, 0x0E, 0x00}, // LATIN SMALL LETTER O WITH STROKE
+	0xF0: {0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A}, // Unknown glyph
+	0xF1: {0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A}, // Unknown glyph
+	0xF2: {0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A}, // Unknown glyph
+	0xF3: {0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A}, // Unknown glyph
+	0xF4: {0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A, 0x15, 0x0A}, // Unknown glyph
+	0xF5: {0x10, 0x10, 0x12, 0x15, 0x01, 0x02, 0x07, 0x00}, // VULGAR FRACTION ONE HALF
+	0xF6: {0x10, 0x10, 0x12, 0x16, 0x0A, 0x0F, 0x02, 0x00}, // VULGAR FRACTION ONE QUARTER
+	0xF7: {0x00, 0x11, 0x0A, 0x04, 0x0A, 0x11, 0x00, 0x00}, // MULTIPLICATION SIGN
+	0xF8: {0x00, 0x04, 0x00, 0x1F, 0x00, 0x04, 0x00, 0x00}, // DIVISION SIGN
+	0xF9: {0x02, 0x04, 0x08, 0x04, 0x02, 0x00, 0x1F, 0x00}, // LESS-THAN OR EQUAL TO
+	0xFA: {0x08, 0x04, 0x02, 0x04, 0x08, 0x00, 0x1F, 0x00}, // GREATER-THAN OR EQUAL TO
+	0xFB: {0x00, 0x05, 0x0A, 0x14, 0x0A, 0x05, 0x00, 0x00}, // MUCH LESS-THAN
+	0xFC: {0x00, 0x14, 0x0A, 0x05, 0x0A, 0x14, 0x00, 0x00}, // MUCH GREATER-THAN
+	0xFD: {0x00, 0x02, 0x1F, 0x04, 0x1F, 0x08, 0x00, 0x00}, // NOT EQUAL TO
+	0xFE: {0x07, 0x04, 0x04, 0x04, 0x14, 0x0C, 0x04, 0x00}, // SQUARE ROOT
+	0xFF: {0x1F, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, // OVERLINE
+}
@@ -0,0 +1,45 @@
+package util
+
+import "testing"
+
+func TestGlyphs(t *testing.T) {
+	blank := [GlyphHeight]byte{}
+	for i := 0; i < 256; i++ {
+		g := Glyph(byte(i))
+		for row, bits := range g {
+			if bits >= 1<<GlyphWidth {
+				t.Errorf("Glyph %#x row %d is wider than %d pixels: %#x", i, row, GlyphWidth, bits)
+			}
+		}
+
+		// Only the space characters are blank
+		if (g == blank) != (i == 0x20 || i == 0xA0) {
+			t.Errorf("Glyph %#x blank mismatch: %#x", i, g)
+		}
+	}
+
+	// Every mapped byte has a distinct glyph from the unknown glyph
+	unknown := Glyph(0x00)
+	for r, c := range charmap {
+		if Glyph(c) == unknown {
+			t.Errorf("Glyph %#x for %U is drawn as unknown", c, r)
+		}
+	}
+
+	// Distinct characters have distinct glyphs, except for the spaces and
+	// the dashes, which look the same on the display
+	same := map[byte]byte{0xA0: 0x20, 0xB0: 0x2D}
+	seen := make(map[[GlyphHeight]byte]byte)
+	for i := 0; i < 256; i++ {
+		c := byte(i)
+		g := Glyph(c)
+		if g == unknown {
+			continue
+		}
+
+		if prev, ok := seen[g]; ok && same[c] != prev {
+			t.Errorf("Glyph %#x is the same as glyph %#x", c, prev)
+		}
+		seen[g] = c
+	}
+}
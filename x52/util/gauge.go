@@ -0,0 +1,168 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"math"
+)
+
+// The MFD code page has no block elements, so the gauges are drawn with the
+// line glyphs that it does have
+
+// Default glyphs for a Bar
+const (
+	barFull  = '='
+	barHalf  = '-'
+	barEmpty = 0x20
+)
+
+// sparkGlyphs are the glyphs for the three levels within a single line of a
+// Sparkline, from the bottom of the character cell to the top
+var sparkGlyphs = [3]byte{'_', '-', 0xFF}
+
+// gaugeScale returns the fraction of the range covered by the value, limited
+// to the range [0, 1]
+func gaugeScale(value, min, max float64) float64 {
+	f := (value - min) / (max - min)
+	if f < 0 || math.IsNaN(f) {
+		return 0
+	}
+	if f > 1 {
+		return 1
+	}
+	return f
+}
+
+// Bar is a Widget that shows a value as a horizontal bar gauge on a single
+// line, filled from the left in steps of half a character
+type Bar struct {
+	width    int
+	min, max float64
+	value    float64
+	full     byte
+	half     byte
+	empty    byte
+}
+
+// NewBar returns an empty Bar of the given width for values between min and
+// max. By default, full cells are drawn with '=', half cells with '-' and
+// empty cells with a space.
+func NewBar(width int, min, max float64) (*Bar, error) {
+	if width < 1 || width > mfdLineSize {
+		return nil, errors.New("width out of range")
+	}
+	if !(min < max) {
+		return nil, errors.New("invalid range")
+	}
+
+	return &Bar{
+		width: width,
+		min:   min,
+		max:   max,
+		value: min,
+		full:  barFull,
+		half:  barHalf,
+		empty: barEmpty,
+	}, nil
+}
+
+// SetValue sets the value shown by the bar. Values outside the range of the
+// bar are shown as an empty or a full bar.
+func (b *Bar) SetValue(value float64) {
+	b.value = value
+}
+
+// SetGlyphs sets the bytes in the MFD code page used to draw full, half and
+// empty cells of the bar
+func (b *Bar) SetGlyphs(full, half, empty byte) {
+	b.full = full
+	b.half = half
+	b.empty = empty
+}
+
+// Size returns the size of the bar
+func (b *Bar) Size() (width, height int) {
+	return b.width, 1
+}
+
+// Draw returns the bar for the current value
+func (b *Bar) Draw() [][]byte {
+	halves := int(math.Round(gaugeScale(b.value, b.min, b.max) * float64(2*b.width)))
+
+	line := bytes.Repeat([]byte{b.empty}, b.width)
+	for i := 0; i < halves/2; i++ {
+		line[i] = b.full
+	}
+	if halves%2 != 0 {
+		line[halves/2] = b.half
+	}
+
+	return [][]byte{line}
+}
+
+// Sparkline is a Widget that plots the most recent values over all three
+// lines of the MFD, with one value per column and the newest value in the
+// rightmost column. Each line has three levels, so each value is shown at one
+// of nine heights.
+type Sparkline struct {
+	width    int
+	min, max float64
+	samples  []float64
+}
+
+// NewSparkline returns an empty Sparkline of the given width for values
+// between min and max
+func NewSparkline(width int, min, max float64) (*Sparkline, error) {
+	if width < 1 || width > mfdLineSize {
+		return nil, errors.New("width out of range")
+	}
+	if !(min < max) {
+		return nil, errors.New("invalid range")
+	}
+
+	return &Sparkline{
+		width: width,
+		min:   min,
+		max:   max,
+	}, nil
+}
+
+// Push adds a value to the right of the sparkline, and discards the oldest
+// value once the sparkline is full. A NaN value leaves a gap in the plot.
+func (s *Sparkline) Push(value float64) {
+	s.samples = append(s.samples, value)
+	if len(s.samples) > s.width {
+		s.samples = s.samples[len(s.samples)-s.width:]
+	}
+}
+
+// Reset discards every value in the sparkline
+func (s *Sparkline) Reset() {
+	s.samples = nil
+}
+
+// Size returns the size of the sparkline
+func (s *Sparkline) Size() (width, height int) {
+	return s.width, mfdLines
+}
+
+// Draw returns the plot of the values in the sparkline
+func (s *Sparkline) Draw() [][]byte {
+	out := make([][]byte, mfdLines)
+	for i := range out {
+		out[i] = bytes.Repeat([]byte{0x20}, s.width)
+	}
+
+	levels := mfdLines * len(sparkGlyphs)
+	col := s.width - len(s.samples)
+	for _, v := range s.samples {
+		if !math.IsNaN(v) {
+			level := int(math.Round(gaugeScale(v, s.min, s.max) * float64(levels-1)))
+			row := mfdLines - 1 - level/len(sparkGlyphs)
+			out[row][col] = sparkGlyphs[level%len(sparkGlyphs)]
+		}
+		col++
+	}
+
+	return out
+}
@@ -0,0 +1,80 @@
+package util
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBar(t *testing.T) {
+	bar, err := NewBar(5, 0, 100)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	for _, tc := range []struct {
+		value    float64
+		expected string
+	}{
+		{0, "     "},
+		{10, "-    "},
+		{20, "=    "},
+		{55, "===  "},
+		{50, "==-  "},
+		{100, "====="},
+		{150, "====="},
+		{-5, "     "},
+	} {
+		bar.SetValue(tc.value)
+		if got := bar.Draw()[0]; string(got) != tc.expected {
+			t.Errorf("Value %v: expected %q, got %q", tc.value, tc.expected, got)
+		}
+	}
+
+	bar.SetGlyphs('#', '+', '.')
+	bar.SetValue(50)
+	if got := bar.Draw()[0]; string(got) != "##+.." {
+		t.Errorf("Expected custom glyphs, got %q", got)
+	}
+
+	if _, err := NewBar(0, 0, 1); err == nil {
+		t.Error("Expected error for zero width")
+	}
+	if _, err := NewBar(4, 1, 1); err == nil {
+		t.Error("Expected error for empty range")
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	spark, err := NewSparkline(6, 0, 8)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	for _, v := range []float64{0, 1, 2, 3, 8, math.NaN(), 5} {
+		spark.Push(v)
+	}
+
+	// The first value has scrolled off the left of the sparkline
+	expected := []string{
+		"   \xff  ",
+		"  _  \xff",
+		"-\xff    ",
+	}
+
+	lines := spark.Draw()
+	if len(lines) != mfdLines {
+		t.Fatalf("Expected %d lines, got %d", mfdLines, len(lines))
+	}
+	for i, exp := range expected {
+		if string(lines[i]) != exp {
+			t.Errorf("Line %d mismatch, expected %q, got %q", i, exp, lines[i])
+		}
+	}
+
+	spark.Reset()
+	for i, line := range spark.Draw() {
+		if string(line) != "      " {
+			t.Errorf("Line %d not blank after reset, got %q", i, line)
+		}
+	}
+}
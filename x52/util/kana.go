@@ -0,0 +1,137 @@
+package util
+
+// This file handles the conversion of Japanese text to the half-width katakana
+// glyphs in the MFD character map. It is only used when the ConvertKana flag
+// is passed to the conversion functions.
+
+// convertKana returns the half-width equivalent of hiragana, full-width
+// katakana and the full-width forms of ASCII, or false if the rune is not one
+// of these
+func convertKana(r rune) (string, bool) {
+	switch {
+	case r >= 0x3041 && r <= 0x3096:
+		// Hiragana is at a fixed offset from the corresponding katakana
+		r += 0x60
+
+	case r >= 0xFF01 && r <= 0xFF5E:
+		// Full-width ASCII is at a fixed offset from ASCII
+		return string(r - 0xFEE0), true
+	}
+
+	s, ok := kanamap[r]
+	return s, ok
+}
+
+// Conversion table from full-width to half-width katakana. The voiced and
+// semi-voiced syllables don't have half-width forms, and are converted to the
+// base syllable followed by the half-width (semi-)voiced sound mark. The small
+// forms that don't have half-width equivalents are converted to the full size
+// syllable.
+var kanamap = map[rune]string{
+	// CJK punctuation
+	0x3000: " ", // IDEOGRAPHIC SPACE
+	0x3001: "､", // IDEOGRAPHIC COMMA
+	0x3002: "｡", // IDEOGRAPHIC FULL STOP
+	0x300C: "｢", // LEFT CORNER BRACKET
+	0x300D: "｣", // RIGHT CORNER BRACKET
+
+	// Voiced sound marks, both combining and spacing
+	0x3099: "ﾞ", // COMBINING KATAKANA-HIRAGANA VOICED SOUND MARK
+	0x309A: "ﾟ", // COMBINING KATAKANA-HIRAGANA SEMI-VOICED SOUND MARK
+	0x309B: "ﾞ", // KATAKANA-HIRAGANA VOICED SOUND MARK
+	0x309C: "ﾟ", // KATAKANA-HIRAGANA SEMI-VOICED SOUND MARK
+
+	// Katakana
+	0x30A1: "ｧ",  // KATAKANA LETTER SMALL A
+	0x30A2: "ｱ",  // KATAKANA LETTER A
+	0x30A3: "ｨ",  // KATAKANA LETTER SMALL I
+	0x30A4: "ｲ",  // KATAKANA LETTER I
+	0x30A5: "ｩ",  // KATAKANA LETTER SMALL U
+	0x30A6: "ｳ",  // KATAKANA LETTER U
+	0x30A7: "ｪ",  // KATAKANA LETTER SMALL E
+	0x30A8: "ｴ",  // KATAKANA LETTER E
+	0x30A9: "ｫ",  // KATAKANA LETTER SMALL O
+	0x30AA: "ｵ",  // KATAKANA LETTER O
+	0x30AB: "ｶ",  // KATAKANA LETTER KA
+	0x30AC: "ｶﾞ", // KATAKANA LETTER GA
+	0x30AD: "ｷ",  // KATAKANA LETTER KI
+	0x30AE: "ｷﾞ", // KATAKANA LETTER GI
+	0x30AF: "ｸ",  // KATAKANA LETTER KU
+	0x30B0: "ｸﾞ", // KATAKANA LETTER GU
+	0x30B1: "ｹ",  // KATAKANA LETTER KE
+	0x30B2: "ｹﾞ", // KATAKANA LETTER GE
+	0x30B3: "ｺ",  // KATAKANA LETTER KO
+	0x30B4: "ｺﾞ", // KATAKANA LETTER GO
+	0x30B5: "ｻ",  // KATAKANA LETTER SA
+	0x30B6: "ｻﾞ", // KATAKANA LETTER ZA
+	0x30B7: "ｼ",  // KATAKANA LETTER SI
+	0x30B8: "ｼﾞ", // KATAKANA LETTER ZI
+	0x30B9: "ｽ",  // KATAKANA LETTER SU
+	0x30BA: "ｽﾞ", // KATAKANA LETTER ZU
+	0x30BB: "ｾ",  // KATAKANA LETTER SE
+	0x30BC: "ｾﾞ", // KATAKANA LETTER ZE
+	0x30BD: "ｿ",  // KATAKANA LETTER SO
+	0x30BE: "ｿﾞ", // KATAKANA LETTER ZO
+	0x30BF: "ﾀ",  // KATAKANA LETTER TA
+	0x30C0: "ﾀﾞ", // KATAKANA LETTER DA
+	0x30C1: "ﾁ",  // KATAKANA LETTER TI
+	0x30C2: "ﾁﾞ", // KATAKANA LETTER DI
+	0x30C3: "ｯ",  // KATAKANA LETTER SMALL TU
+	0x30C4: "ﾂ",  // KATAKANA LETTER TU
+	0x30C5: "ﾂﾞ", // KATAKANA LETTER DU
+	0x30C6: "ﾃ",  // KATAKANA LETTER TE
+	0x30C7: "ﾃﾞ", // KATAKANA LETTER DE
+	0x30C8: "ﾄ",  // KATAKANA LETTER TO
+	0x30C9: "ﾄﾞ", // KATAKANA LETTER DO
+	0x30CA: "ﾅ",  // KATAKANA LETTER NA
+	0x30CB: "ﾆ",  // KATAKANA LETTER NI
+	0x30CC: "ﾇ",  // KATAKANA LETTER NU
+	0x30CD: "ﾈ",  // KATAKANA LETTER NE
+	0x30CE: "ﾉ",  // KATAKANA LETTER NO
+	0x30CF: "ﾊ",  // KATAKANA LETTER HA
+	0x30D0: "ﾊﾞ", // KATAKANA LETTER BA
+	0x30D1: "ﾊﾟ", // KATAKANA LETTER PA
+	0x30D2: "ﾋ",  // KATAKANA LETTER HI
+	0x30D3: "ﾋﾞ", // KATAKANA LETTER BI
+	0x30D4: "ﾋﾟ", // KATAKANA LETTER PI
+	0x30D5: "ﾌ",  // KATAKANA LETTER HU
+	0x30D6: "ﾌﾞ", // KATAKANA LETTER BU
+	0x30D7: "ﾌﾟ", // KATAKANA LETTER PU
+	0x30D8: "ﾍ",  // KATAKANA LETTER HE
+	0x30D9: "ﾍﾞ", // KATAKANA LETTER BE
+	0x30DA: "ﾍﾟ", // KATAKANA LETTER PE
+	0x30DB: "ﾎ",  // KATAKANA LETTER HO
+	0x30DC: "ﾎﾞ", // KATAKANA LETTER BO
+	0x30DD: "ﾎﾟ", // KATAKANA LETTER PO
+	0x30DE: "ﾏ",  // KATAKANA LETTER MA
+	0x30DF: "ﾐ",  // KATAKANA LETTER MI
+	0x30E0: "ﾑ",  // KATAKANA LETTER MU
+	0x30E1: "ﾒ",  // KATAKANA LETTER ME
+	0x30E2: "ﾓ",  // KATAKANA LETTER MO
+	0x30E3: "ｬ",  // KATAKANA LETTER SMALL YA
+	0x30E4: "ﾔ",  // KATAKANA LETTER YA
+	0x30E5: "ｭ",  // KATAKANA LETTER SMALL YU
+	0x30E6: "ﾕ",  // KATAKANA LETTER YU
+	0x30E7: "ｮ",  // KATAKANA LETTER SMALL YO
+	0x30E8: "ﾖ",  // KATAKANA LETTER YO
+	0x30E9: "ﾗ",  // KATAKANA LETTER RA
+	0x30EA: "ﾘ",  // KATAKANA LETTER RI
+	0x30EB: "ﾙ",  // KATAKANA LETTER RU
+	0x30EC: "ﾚ",  // KATAKANA LETTER RE
+	0x30ED: "ﾛ",  // KATAKANA LETTER RO
+	0x30EE: "ﾜ",  // KATAKANA LETTER SMALL WA
+	0x30EF: "ﾜ",  // KATAKANA LETTER WA
+	0x30F0: "ｲ",  // KATAKANA LETTER WI
+	0x30F1: "ｴ",  // KATAKANA LETTER WE
+	0x30F2: "ｦ",  // KATAKANA LETTER WO
+	0x30F3: "ﾝ",  // KATAKANA LETTER N
+	0x30F4: "ｳﾞ", // KATAKANA LETTER VU
+	0x30F5: "ｶ",  // KATAKANA LETTER SMALL KA
+	0x30F6: "ｹ",  // KATAKANA LETTER SMALL KE
+	0x30F7: "ﾜﾞ", // KATAKANA LETTER VA
+	0x30F8: "ｲﾞ", // KATAKANA LETTER VI
+	0x30F9: "ｴﾞ", // KATAKANA LETTER VE
+	0x30FA: "ｦﾞ", // KATAKANA LETTER VO
+	0x30FB: "･",  // KATAKANA MIDDLE DOT
+	0x30FC: "ｰ",  // KATAKANA-HIRAGANA PROLONGED SOUND MARK
+}
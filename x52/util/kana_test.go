@@ -0,0 +1,63 @@
+package util
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestKanaTable(t *testing.T) {
+	// Every entry in the table must convert to runes in the character map
+	for k, v := range kanamap {
+		for _, r := range v {
+			if _, ok := charmap[r]; !ok {
+				t.Errorf("Conversion of %U contains unmapped rune %U", k, r)
+			}
+		}
+	}
+}
+
+func TestKana(t *testing.T) {
+	tests := []struct {
+		input string
+		exp   []byte
+	}{
+		// Full-width katakana
+		{"カタカナ", []byte{0xB6, 0xC0, 0xB6, 0xC5}},
+		// Hiragana
+		{"ひらがな", []byte{0xCB, 0xD7, 0xB6, 0xDE, 0xC5}},
+		// Voiced and semi-voiced syllables
+		{"ガギグゲゴ", []byte{0xB6, 0xDE, 0xB7, 0xDE, 0xB8, 0xDE, 0xB9, 0xDE, 0xBA, 0xDE}},
+		{"ぱぴぷぺぽ", []byte{0xCA, 0xDF, 0xCB, 0xDF, 0xCC, 0xDF, 0xCD, 0xDF, 0xCE, 0xDF}},
+		{"ヴ", []byte{0xB3, 0xDE}},
+		// Combining voiced sound marks
+		{"\u30AB\u3099", []byte{0xB6, 0xDE}},
+		// Small kana and punctuation
+		{"「ちょっと」、ね。", []byte{0xA2, 0xC1, 0xAE, 0xAF, 0xC4, 0xA3, 0xA4, 0xC8, 0xA1}},
+		{"コーヒー・ミルク", []byte{0xBA, 0xB0, 0xCB, 0xB0, 0xA5, 0xD0, 0xD9, 0xB8}},
+		// Full-width ASCII
+		{"ＡＢＣ　１２３！", []byte("ABC 123!")},
+		// Half-width katakana is unchanged
+		{"ｶﾞ", []byte{0xB6, 0xDE}},
+		// Kanji are not converted
+		{"日本", []byte{ReplaceMissing, ReplaceMissing}},
+	}
+
+	for _, tc := range tests {
+		got := ConvertString(tc.input, ConvertReplace|ConvertKana, ReplaceMissing)
+		if !bytes.Equal(got, tc.exp) {
+			t.Errorf("Converting %q, expected %#x, got %#x", tc.input, tc.exp, got)
+		}
+	}
+
+	// Without the flag, none of the kana are converted
+	got := ConvertString("ガがｶ", ConvertReplace, ReplaceMissing)
+	if exp := []byte{ReplaceMissing, ReplaceMissing, 0xB6}; !bytes.Equal(got, exp) {
+		t.Errorf("Expected %#x, got %#x", exp, got)
+	}
+
+	// Transliteration composes the combining marks before conversion
+	got = ConvertString("\u30CF\u309A\u30F3", ConvertKana|ConvertTransliterate, ReplaceMissing)
+	if exp := []byte{0xCA, 0xDF, 0xDD}; !bytes.Equal(got, exp) {
+		t.Errorf("Expected %#x, got %#x", exp, got)
+	}
+}
@@ -0,0 +1,151 @@
+package util
+
+import (
+	"bytes"
+	"strings"
+)
+
+// LayoutFlags control the placement of text within an MFD line
+type LayoutFlags uint
+
+const (
+	// AlignLeft places the text at the start of the line. This is the
+	// default alignment
+	AlignLeft LayoutFlags = 0
+
+	// AlignRight places the text at the end of the line
+	AlignRight LayoutFlags = 1 << iota
+
+	// AlignCenter places the text in the middle of the line. If the text
+	// cannot be centered exactly, it is placed one character to the left.
+	AlignCenter
+
+	// LayoutEllipsis marks text that has been truncated with an ellipsis,
+	// instead of silently cutting it off at the end of the line
+	LayoutEllipsis
+)
+
+// ellipsis is used to mark truncated text. The MFD code page has no
+// horizontal ellipsis glyph, so this uses three periods instead.
+var ellipsis = []byte("...")
+
+// layoutConvert converts the string to the MFD code page for the layout
+// functions. Unrecognized runes are replaced so that the width of the text
+// is preserved.
+func layoutConvert(s string) []byte {
+	return ConvertStringToX52Charmap(s, true, ReplaceMissing)
+}
+
+// truncate shortens data to fit in width bytes, adding an ellipsis if the
+// flags request it
+func truncate(data []byte, width int, flags LayoutFlags) []byte {
+	if len(data) <= width {
+		return data
+	}
+
+	if flags&LayoutEllipsis != 0 && width > len(ellipsis) {
+		out := append([]byte(nil), data[:width-len(ellipsis)]...)
+		return append(out, ellipsis...)
+	}
+
+	return data[:width]
+}
+
+// alignLine pads data, which must already be in the MFD code page, to a full
+// line with the given alignment
+func alignLine(data []byte, flags LayoutFlags) []byte {
+	data = truncate(data, mfdLineSize, flags)
+
+	line := bytes.Repeat([]byte{0x20}, mfdLineSize)
+	pad := mfdLineSize - len(data)
+	switch {
+	case flags&AlignRight != 0:
+		copy(line[pad:], data)
+
+	case flags&AlignCenter != 0:
+		copy(line[pad/2:], data)
+
+	default:
+		copy(line, data)
+	}
+
+	return line
+}
+
+// FormatLine converts the string to the MFD code page and returns a full 16
+// byte line with the text placed according to the flags. Text that is too
+// long for the line is truncated.
+func FormatLine(s string, flags LayoutFlags) []byte {
+	return alignLine(layoutConvert(s), flags)
+}
+
+// FormatColumns returns a full 16 byte line with the label placed at the start
+// of the line and the value at the end. The value takes priority, and the
+// label is truncated if both do not fit with at least one space between them.
+func FormatColumns(label, value string, flags LayoutFlags) []byte {
+	labelData := layoutConvert(label)
+	valueData := truncate(layoutConvert(value), mfdLineSize, flags)
+
+	width := mfdLineSize - len(valueData) - 1
+	if width < 0 {
+		width = 0
+	}
+	labelData = truncate(labelData, width, flags)
+
+	line := bytes.Repeat([]byte{0x20}, mfdLineSize)
+	copy(line, labelData)
+	copy(line[mfdLineSize-len(valueData):], valueData)
+
+	return line
+}
+
+// WrapText converts the string to the MFD code page and wraps it at word
+// boundaries over up to 3 lines, each of which is a full 16 byte line placed
+// according to the flags. Words that are longer than a line are split. Text
+// that does not fit in 3 lines is truncated, and marked with an ellipsis on the
+// last line if requested.
+func WrapText(s string, flags LayoutFlags) [][]byte {
+	var lines [][]byte
+	var current []byte
+
+	for _, word := range strings.Fields(s) {
+		data := layoutConvert(word)
+
+		// Start a new line if the word does not fit on the current one
+		if len(current) != 0 && len(current)+1+len(data) > mfdLineSize {
+			lines = append(lines, current)
+			current = nil
+		}
+
+		if len(current) != 0 {
+			current = append(current, 0x20)
+		}
+		current = append(current, data...)
+
+		// Split words that are too long to fit on a single line
+		for len(current) > mfdLineSize {
+			lines = append(lines, current[:mfdLineSize])
+			current = current[mfdLineSize:]
+		}
+	}
+
+	if len(current) != 0 {
+		lines = append(lines, current)
+	}
+
+	if len(lines) > mfdLines {
+		// Mark the truncation by forcing the ellipsis onto the last line
+		last := append([]byte(nil), lines[mfdLines-1]...)
+		lines = lines[:mfdLines]
+		if flags&LayoutEllipsis != 0 {
+			last = append(truncate(last, mfdLineSize-len(ellipsis), 0), ellipsis...)
+		}
+		lines[mfdLines-1] = last
+	}
+
+	for i := range lines {
+		lines[i] = alignLine(lines[i], flags)
+	}
+
+	return lines
+}
@@ -0,0 +1,137 @@
+package util
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFormatLine(t *testing.T) {
+	tests := []struct {
+		text  string
+		flags LayoutFlags
+		exp   string
+	}{
+		{"Hello", AlignLeft, "Hello           "},
+		{"Hello", AlignRight, "           Hello"},
+		{"Hello", AlignCenter, "     Hello      "},
+		{"Hello!", AlignCenter, "     Hello!     "},
+		{"", AlignCenter, "                "},
+		{"A very long line of text", AlignLeft, "A very long line"},
+		{"A very long line of text", AlignRight, "A very long line"},
+		{"A very long line of text", LayoutEllipsis, "A very long l..."},
+		{"Exactly 16 chars", LayoutEllipsis, "Exactly 16 chars"},
+		{"Ça va", AlignRight, "           \x80a va"},
+	}
+
+	for _, tc := range tests {
+		got := FormatLine(tc.text, tc.flags)
+		if !bytes.Equal(got, []byte(tc.exp)) {
+			t.Errorf("FormatLine(%q, %v):\n\texp: %q\n\tgot: %q", tc.text, tc.flags, tc.exp, got)
+		}
+	}
+}
+
+func TestFormatColumns(t *testing.T) {
+	tests := []struct {
+		label string
+		value string
+		flags LayoutFlags
+		exp   string
+	}{
+		{"ALT", "12000", 0, "ALT        12000"},
+		{"", "12000", 0, "           12000"},
+		{"ALT", "", 0, "ALT             "},
+		{"HEADING", "270 MAG", 0, "HEADING  270 MAG"},
+		{"TEMPERATURE", "-12.5C", 0, "TEMPERATU -12.5C"},
+		{"TEMPERATURE", "-12.5C", LayoutEllipsis, "TEMPER... -12.5C"},
+		{"LABEL", "A value that is too long", 0, "A value that is "},
+	}
+
+	for _, tc := range tests {
+		got := FormatColumns(tc.label, tc.value, tc.flags)
+		if !bytes.Equal(got, []byte(tc.exp)) {
+			t.Errorf("FormatColumns(%q, %q, %v):\n\texp: %q\n\tgot: %q",
+				tc.label, tc.value, tc.flags, tc.exp, got)
+		}
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		text  string
+		flags LayoutFlags
+		exp   []string
+	}{
+		{"", 0, nil},
+		{"Short", AlignCenter, []string{"     Short      "}},
+		{
+			"The quick brown fox jumps over the lazy dog",
+			AlignLeft,
+			[]string{
+				"The quick brown ",
+				"fox jumps over  ",
+				"the lazy dog    ",
+			},
+		},
+		{
+			"The  quick\tbrown\nfox",
+			AlignRight,
+			[]string{
+				" The quick brown",
+				"             fox",
+			},
+		},
+		{
+			"Supercalifragilisticexpialidocious",
+			AlignLeft,
+			[]string{
+				"Supercalifragili",
+				"sticexpialidocio",
+				"us              ",
+			},
+		},
+		{
+			"The quick brown fox jumps over the lazy dog again and again",
+			AlignLeft,
+			[]string{
+				"The quick brown ",
+				"fox jumps over  ",
+				"the lazy dog    ",
+			},
+		},
+		{
+			"The quick brown fox jumps over the lazy dog again and again",
+			LayoutEllipsis,
+			[]string{
+				"The quick brown ",
+				"fox jumps over  ",
+				"the lazy dog... ",
+			},
+		},
+		{
+			"The quick brown fox jumps over a lazy dog again and again",
+			LayoutEllipsis,
+			[]string{
+				"The quick brown ",
+				"fox jumps over a",
+				"lazy dog agai...",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		got := WrapText(tc.text, tc.flags)
+		if len(got) != len(tc.exp) {
+			t.Errorf("WrapText(%q, %v): expected %d lines, got %d: %q",
+				tc.text, tc.flags, len(tc.exp), len(got), got)
+			continue
+		}
+
+		for i := range got {
+			if !bytes.Equal(got[i], []byte(tc.exp[i])) {
+				t.Errorf("WrapText(%q, %v) line %d:\n\texp: %q\n\tgot: %q",
+					tc.text, tc.flags, i, tc.exp[i], got[i])
+			}
+		}
+	}
+}
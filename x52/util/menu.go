@@ -0,0 +1,306 @@
+package util
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MenuItem is an entry in a Menu. An item with sub-items opens a submenu when
+// it is selected, an item with a value starts editing the value, and any other
+// item calls its action.
+type MenuItem struct {
+	// Label is the text shown for the item, and the header of its submenu
+	Label string
+
+	// Items are the entries of the submenu opened by the item
+	Items []*MenuItem
+
+	// Value is the setting edited by the item
+	Value *MenuValue
+
+	// Action is called when the item is selected
+	Action func()
+}
+
+// MenuValue is an integer setting that can be edited from a Menu. If Options
+// is set, then the value is an index into the options, which are shown in
+// place of the number, otherwise the value is limited to the range from Min
+// to Max.
+type MenuValue struct {
+	Value    int
+	Min, Max int
+
+	// Step is the amount by which the value changes, a Step of 0 is the
+	// same as a Step of 1
+	Step int
+
+	// Options are the names of the values, if any
+	Options []string
+
+	// OnChange is called with the new value when an edit is confirmed
+	OnChange func(value int)
+}
+
+// String returns the text shown for the value
+func (mv *MenuValue) String() string {
+	if len(mv.Options) != 0 {
+		if mv.Value < 0 || mv.Value >= len(mv.Options) {
+			return ""
+		}
+		return mv.Options[mv.Value]
+	}
+
+	return strconv.Itoa(mv.Value)
+}
+
+// adjust changes the value by a step in the given direction, limited to the
+// valid range
+func (mv *MenuValue) adjust(dir int) {
+	min, max, step := mv.Min, mv.Max, mv.Step
+	if len(mv.Options) != 0 {
+		min, max, step = 0, len(mv.Options)-1, 1
+	}
+	if step == 0 {
+		step = 1
+	}
+
+	value := mv.Value + dir*step
+	if value > max {
+		value = max
+	}
+	if value < min {
+		value = min
+	}
+	mv.Value = value
+}
+
+// Menu shows a hierarchical menu on the MFD. The label of the current menu is
+// shown on the first line, and the remaining lines show the items, with an
+// arrow marking the selected item. The menu is driven by calling Up, Down,
+// Select and Back, typically from the input handling of the application, and
+// the lines can be bound to a ScrollManager or a Pager with Line. It is safe
+// to drive the menu while the lines are being advanced by a ScrollManager.
+type Menu struct {
+	mu      sync.Mutex
+	stack   []menuLevel
+	editing bool
+	saved   int
+}
+
+// menuLevel is the state of one level of an open Menu
+type menuLevel struct {
+	item     *MenuItem
+	selected int
+	top      int
+}
+
+// NewMenu returns a Menu showing the items of the root item, with the first
+// item selected
+func NewMenu(root *MenuItem) *Menu {
+	return &Menu{stack: []menuLevel{{item: root}}}
+}
+
+// Up selects the previous item, or increases the value while editing
+func (m *Menu) Up() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.move(-1)
+}
+
+// Down selects the next item, or decreases the value while editing
+func (m *Menu) Down() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.move(1)
+}
+
+// Select activates the selected item. This opens its submenu, starts editing
+// its value, or calls its action. While editing, Select confirms the new value
+// and calls the OnChange function of the value.
+func (m *Menu) Select() {
+	m.mu.Lock()
+
+	item := m.selected()
+	if item == nil {
+		m.mu.Unlock()
+		return
+	}
+
+	var callback func()
+	switch {
+	case m.editing:
+		m.editing = false
+		if item.Value.OnChange != nil {
+			onChange, value := item.Value.OnChange, item.Value.Value
+			callback = func() { onChange(value) }
+		}
+
+	case len(item.Items) != 0:
+		m.stack = append(m.stack, menuLevel{item: item})
+
+	case item.Value != nil:
+		m.editing = true
+		m.saved = item.Value.Value
+
+	default:
+		callback = item.Action
+	}
+
+	// The callback may drive the menu, so it must be called without the
+	// lock held
+	m.mu.Unlock()
+	if callback != nil {
+		callback()
+	}
+}
+
+// Back cancels editing and restores the previous value, or closes the current
+// submenu. It returns false if the menu is already at the top level.
+func (m *Menu) Back() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.editing {
+		m.selected().Value.Value = m.saved
+		m.editing = false
+		return true
+	}
+
+	if len(m.stack) > 1 {
+		m.stack = m.stack[:len(m.stack)-1]
+		return true
+	}
+
+	return false
+}
+
+// Editing returns true if the value of the selected item is being edited
+func (m *Menu) Editing() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.editing
+}
+
+// Path returns the labels of the open menus, starting with the root
+func (m *Menu) Path() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	path := make([]string, len(m.stack))
+	for i, level := range m.stack {
+		path[i] = level.item.Label
+	}
+	return path
+}
+
+// Selected returns the selected item of the current menu, or nil if the
+// current menu has no items
+func (m *Menu) Selected() *MenuItem {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.selected()
+}
+
+// Lines returns the lines of the menu for passing to the MFD text API, each
+// padded to the full width of the MFD. While editing, the value of the
+// selected item is shown in brackets.
+func (m *Menu) Lines() [mfdLines][]byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	level := m.stack[len(m.stack)-1]
+
+	var out [mfdLines][]byte
+	out[0] = FormatLine(level.item.Label, LayoutEllipsis)
+	for row := 1; row < mfdLines; row++ {
+		idx := level.top + row - 1
+		if idx >= len(level.item.Items) {
+			out[row] = alignLine(nil, AlignLeft)
+			continue
+		}
+
+		item := level.item.Items[idx]
+		marker := " "
+		if idx == level.selected {
+			marker = "→"
+		}
+
+		var value string
+		switch {
+		case item.Value != nil && m.editing && idx == level.selected:
+			value = "[" + item.Value.String() + "]"
+		case item.Value != nil:
+			value = item.Value.String()
+		case len(item.Items) != 0:
+			value = ">"
+		}
+
+		out[row] = FormatColumns(marker+item.Label, value, LayoutEllipsis)
+	}
+
+	return out
+}
+
+// Line returns a LineSource for a single line of the MFD, which returns the
+// corresponding line of the menu. It returns an error if the line is not on
+// the MFD.
+func (m *Menu) Line(line int) (LineSource, error) {
+	if line < 0 || line >= mfdLines {
+		return nil, errors.New("line number out of range")
+	}
+
+	return menuLine{m, line}, nil
+}
+
+// selected returns the selected item of the current menu, if any
+func (m *Menu) selected() *MenuItem {
+	level := m.stack[len(m.stack)-1]
+	if level.selected >= len(level.item.Items) {
+		return nil
+	}
+
+	return level.item.Items[level.selected]
+}
+
+// move moves the selection, or adjusts the value while editing
+func (m *Menu) move(dir int) {
+	if m.editing {
+		// Up increases the value, which is the opposite direction to
+		// the selection
+		m.selected().Value.adjust(-dir)
+		return
+	}
+
+	level := &m.stack[len(m.stack)-1]
+	selected := level.selected + dir
+	if selected < 0 || selected >= len(level.item.Items) {
+		return
+	}
+	level.selected = selected
+
+	// Keep the selected item visible below the header
+	visible := mfdLines - 1
+	if level.selected < level.top {
+		level.top = level.selected
+	}
+	if level.selected >= level.top+visible {
+		level.top = level.selected - visible + 1
+	}
+}
+
+// menuLine is a LineSource for a single line of a Menu
+type menuLine struct {
+	m    *Menu
+	line int
+}
+
+// Advance returns the line of the menu
+func (ml menuLine) Advance(now time.Time) []byte {
+	return ml.m.Lines()[ml.line]
+}
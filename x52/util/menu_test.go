@@ -0,0 +1,112 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMenu(t *testing.T) {
+	var actions int
+	var changed []int
+
+	brightness := &MenuValue{Value: 64, Min: 0, Max: 128, Step: 32,
+		OnChange: func(v int) { changed = append(changed, v) }}
+	mode := &MenuValue{Options: []string{"OFF", "ON"}}
+
+	root := &MenuItem{
+		Label: "MENU",
+		Items: []*MenuItem{
+			{Label: "Display", Items: []*MenuItem{
+				{Label: "Bright", Value: brightness},
+				{Label: "Blink", Value: mode},
+			}},
+			{Label: "Reset", Action: func() { actions++ }},
+			{Label: "About"},
+		},
+	}
+
+	m := NewMenu(root)
+	check := func(expected ...string) {
+		t.Helper()
+		got := m.Lines()
+		for i, exp := range expected {
+			if string(got[i]) != exp {
+				t.Errorf("Line %d mismatch, expected %q, got %q", i, exp, got[i])
+			}
+		}
+	}
+
+	check("MENU            ", "\x7eDisplay       >", " Reset          ")
+
+	// Moving past the visible items scrolls the menu
+	m.Down()
+	m.Down()
+	m.Down()
+	check("MENU            ", " Reset          ", "\x7eAbout          ")
+
+	m.Up()
+	m.Select()
+	if actions != 1 {
+		t.Errorf("Expected 1 action, got %d", actions)
+	}
+
+	// Open the submenu and edit the brightness
+	m.Up()
+	m.Select()
+	if path := m.Path(); !reflect.DeepEqual(path, []string{"MENU", "Display"}) {
+		t.Errorf("Unexpected path %v", path)
+	}
+	check("Display         ", "\x7eBright       64", " Blink       OFF")
+
+	m.Select()
+	if !m.Editing() {
+		t.Error("Expected to be editing")
+	}
+	m.Up()
+	m.Up()
+	check("Display         ", "\x7eBright    [128]")
+
+	// Cancelling restores the previous value
+	m.Back()
+	if m.Editing() || brightness.Value != 64 {
+		t.Errorf("Expected edit to be cancelled, got value %d", brightness.Value)
+	}
+
+	m.Select()
+	m.Down()
+	m.Down()
+	m.Down()
+	m.Select()
+	if brightness.Value != 0 || !reflect.DeepEqual(changed, []int{0}) {
+		t.Errorf("Expected value 0 to be confirmed, got %d and %v", brightness.Value, changed)
+	}
+
+	m.Down()
+	m.Select()
+	m.Up()
+	m.Select()
+	check("Display         ", " Bright        0", "\x7eBlink        ON")
+	if m.Selected() != root.Items[0].Items[1] {
+		t.Error("Unexpected selected item")
+	}
+
+	if !m.Back() {
+		t.Error("Expected to leave the submenu")
+	}
+	if m.Back() {
+		t.Error("Expected to be at the top level")
+	}
+	check("MENU            ", "\x7eDisplay       >")
+
+	src, err := m.Line(1)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	if got := src.Advance(time.Time{}); string(got) != "\x7eDisplay       >" {
+		t.Errorf("Unexpected line %q", got)
+	}
+	if _, err := m.Line(mfdLines); err == nil {
+		t.Error("Expected error for line out of range")
+	}
+}
@@ -0,0 +1,321 @@
+package util
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Indicators is the subset of the X52 context API used by a Notifier to draw
+// attention to a notification. It is satisfied by *x52.Context.
+type Indicators interface {
+	SetBlink(enable bool) error
+	SetShift(enable bool) error
+}
+
+// NotifyFlags control how a notification draws attention to itself
+type NotifyFlags uint
+
+const (
+	// NotifyFlash blinks the info and POV LEDs while the notification is
+	// shown
+	NotifyFlash NotifyFlags = 1 << iota
+
+	// NotifyShift blinks the shift indicator on the MFD while the
+	// notification is shown
+	NotifyShift
+)
+
+// DefaultNotifyDuration is the time a notification is shown for if its
+// duration is not set
+const DefaultNotifyDuration = 3 * time.Second
+
+// notifyShiftPeriod is the time the shift indicator spends on, and then off,
+// when blinking
+const notifyShiftPeriod = 500 * time.Millisecond
+
+// Notification is a short message that is shown over the contents of the MFD
+type Notification struct {
+	// Text is the message, which is wrapped over up to 3 lines and centered
+	// on the MFD
+	Text string
+
+	// Duration is the time the message is shown for. If it is not set,
+	// then DefaultNotifyDuration is used.
+	Duration time.Duration
+
+	// Priority orders the queue of messages. A message with a higher
+	// priority is shown first, and interrupts a message with a lower
+	// priority, which is shown again for the rest of its duration once the
+	// queue allows. Messages with the same priority are shown in the order
+	// they were sent.
+	Priority int
+
+	// Flags control how the message draws attention to itself
+	Flags NotifyFlags
+}
+
+// notifyEntry is a queued notification
+type notifyEntry struct {
+	lines     [mfdLines][]byte
+	remaining time.Duration
+	priority  int
+	flags     NotifyFlags
+	shown     time.Time
+}
+
+// Notifier shows transient notifications over the MFD. It implements
+// Display, and the application writes the regular contents of the MFD through
+// it, either directly or with a Screen, Layout or ScrollManager. While a
+// notification is shown, the regular contents are recorded but not written,
+// and they are restored automatically once every notification has expired.
+// Notifications are queued by Notify, and shown and expired by Tick or Run.
+// It is safe to call Notify and write the regular contents while Run is
+// active.
+type Notifier struct {
+	display    Display
+	indicators Indicators
+
+	mu        sync.Mutex
+	base      [mfdLines][]byte
+	baseBlink bool
+	baseShift bool
+	shift     bool
+	queue     []*notifyEntry
+	active    *notifyEntry
+	dirty     bool
+}
+
+// NewNotifier returns a Notifier that writes to the given display. The
+// indicators are used for NotifyFlash and NotifyShift, and may be nil if
+// they are not supported, in which case those flags are ignored.
+func NewNotifier(display Display, indicators Indicators) *Notifier {
+	return &Notifier{
+		display:    display,
+		indicators: indicators,
+	}
+}
+
+// SetMFDText sets the regular contents of the MFD line, which are written to
+// the display immediately unless a notification is shown
+func (n *Notifier) SetMFDText(line uint8, data []byte) error {
+	if line >= mfdLines {
+		return errors.New("line number out of range")
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.base[line] = append([]byte(nil), data...)
+	if n.active != nil {
+		return nil
+	}
+	return n.display.SetMFDText(line, data)
+}
+
+// Update updates the display
+func (n *Notifier) Update() error {
+	return n.display.Update()
+}
+
+// SetBlink sets the regular state of the blink functionality, which is
+// overridden while a notification with NotifyFlash is shown
+func (n *Notifier) SetBlink(enable bool) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.baseBlink = enable
+	if n.indicators == nil || (n.active != nil && n.active.flags&NotifyFlash != 0) {
+		return nil
+	}
+	return n.indicators.SetBlink(enable)
+}
+
+// SetShift sets the regular state of the shift indicator, which is overridden
+// while a notification with NotifyShift is shown
+func (n *Notifier) SetShift(enable bool) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.baseShift = enable
+	if n.indicators == nil || (n.active != nil && n.active.flags&NotifyShift != 0) {
+		return nil
+	}
+
+	n.shift = enable
+	return n.indicators.SetShift(enable)
+}
+
+// Notify adds the notification to the queue. It is shown on the next call to
+// Tick, if no notification with the same or a higher priority is shown.
+func (n *Notifier) Notify(msg Notification) {
+	entry := &notifyEntry{
+		remaining: msg.Duration,
+		priority:  msg.Priority,
+		flags:     msg.Flags,
+	}
+	if entry.remaining <= 0 {
+		entry.remaining = DefaultNotifyDuration
+	}
+
+	// Center the text vertically
+	text := WrapText(msg.Text, AlignCenter|LayoutEllipsis)
+	for i := range entry.lines {
+		entry.lines[i] = alignLine(nil, AlignLeft)
+	}
+	copy(entry.lines[(mfdLines-len(text))/2:], text)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.enqueue(entry, false)
+}
+
+// Dismiss removes the notification that is shown, if any. The next
+// notification in the queue, or the regular contents, are shown on the next
+// call to Tick.
+func (n *Notifier) Dismiss() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.active != nil {
+		n.active.remaining = 0
+	}
+}
+
+// Pending returns the number of notifications that are shown or queued
+func (n *Notifier) Pending() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	pending := len(n.queue)
+	if n.active != nil {
+		pending++
+	}
+	return pending
+}
+
+// Tick expires the notification that is shown once its duration has passed
+// at the given time, shows the next notification in the queue, or restores the
+// regular contents of the MFD, and blinks the shift indicator. The display is
+// updated if anything has changed.
+func (n *Notifier) Tick(now time.Time) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	active := n.active
+	if active != nil {
+		elapsed := now.Sub(active.shown)
+		switch {
+		case elapsed >= active.remaining:
+			n.active = nil
+
+		case len(n.queue) != 0 && n.queue[0].priority > active.priority:
+			// Interrupt the message, and show the rest of it later
+			active.remaining -= elapsed
+			n.active = nil
+			n.enqueue(active, true)
+		}
+	}
+
+	if n.active == nil && len(n.queue) != 0 {
+		n.active = n.queue[0]
+		n.queue = n.queue[1:]
+		n.active.shown = now
+	}
+
+	if n.active != active {
+		if err := n.show(); err != nil {
+			return err
+		}
+	}
+
+	if n.active != nil && n.active.flags&NotifyShift != 0 && n.indicators != nil {
+		shift := (now.Sub(n.active.shown)/notifyShiftPeriod)%2 == 0
+		if shift != n.shift {
+			if err := n.indicators.SetShift(shift); err != nil {
+				return err
+			}
+			n.shift = shift
+			n.dirty = true
+		}
+	}
+
+	if !n.dirty {
+		return nil
+	}
+
+	n.dirty = false
+	return n.display.Update()
+}
+
+// Run calls Tick at the given interval until the stop channel is closed, or
+// Tick returns an error, which is then returned by Run
+func (n *Notifier) Run(interval time.Duration, stop <-chan struct{}) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	if err := n.Tick(time.Now()); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case now := <-ticker.C:
+			if err := n.Tick(now); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// enqueue inserts the entry after every entry with a higher priority. A new
+// entry is also inserted after the entries with the same priority, and an
+// interrupted entry before them, so that it is resumed first.
+func (n *Notifier) enqueue(entry *notifyEntry, resume bool) {
+	i := 0
+	for i < len(n.queue) && (n.queue[i].priority > entry.priority ||
+		(!resume && n.queue[i].priority == entry.priority)) {
+		i++
+	}
+
+	n.queue = append(n.queue, nil)
+	copy(n.queue[i+1:], n.queue[i:])
+	n.queue[i] = entry
+}
+
+// show writes the lines and indicators for the active notification, or the
+// regular contents if there is none
+func (n *Notifier) show() error {
+	lines := n.base
+	var flags NotifyFlags
+	if n.active != nil {
+		lines = n.active.lines
+		flags = n.active.flags
+	}
+
+	for i, data := range lines {
+		if err := n.display.SetMFDText(uint8(i), data); err != nil {
+			return err
+		}
+	}
+
+	if n.indicators != nil {
+		if err := n.indicators.SetBlink(n.baseBlink || flags&NotifyFlash != 0); err != nil {
+			return err
+		}
+
+		// A blinking shift indicator is updated by Tick
+		if flags&NotifyShift == 0 {
+			if err := n.indicators.SetShift(n.baseShift); err != nil {
+				return err
+			}
+			n.shift = n.baseShift
+		}
+	}
+
+	n.dirty = true
+	return nil
+}
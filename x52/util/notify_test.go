@@ -0,0 +1,169 @@
+package util
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// testIndicators records the state set through the Indicators interface
+type testIndicators struct {
+	blink  bool
+	shift  bool
+	shifts int
+}
+
+func (ti *testIndicators) SetBlink(enable bool) error {
+	ti.blink = enable
+	return nil
+}
+
+func (ti *testIndicators) SetShift(enable bool) error {
+	ti.shift = enable
+	ti.shifts++
+	return nil
+}
+
+func TestNotifier(t *testing.T) {
+	td := &testDisplay{}
+	ti := &testIndicators{}
+	n := NewNotifier(td, ti)
+
+	base := time.Unix(1600000000, 0)
+	tick := func(ms int) {
+		t.Helper()
+		if err := n.Tick(base.Add(time.Duration(ms) * time.Millisecond)); err != nil {
+			t.Fatal("Unexpected error", err)
+		}
+	}
+	checkLine := func(line int, expected string) {
+		t.Helper()
+		if !bytes.Equal(td.lines[line], []byte(expected)) {
+			t.Errorf("Line %d mismatch, expected %q, got %q", line, expected, td.lines[line])
+		}
+	}
+
+	// Regular contents are written straight through
+	n.SetMFDText(0, []byte("HDG 270"))
+	n.SetMFDText(1, []byte("ALT 5000"))
+	n.SetShift(true)
+	checkLine(0, "HDG 270")
+	if !ti.shift {
+		t.Error("Expected shift to be on")
+	}
+
+	// Nothing is shown until the next tick
+	n.Notify(Notification{Text: "AP DISENGAGED", Duration: 2 * time.Second, Flags: NotifyFlash})
+	checkLine(0, "HDG 270")
+	if n.Pending() != 1 {
+		t.Errorf("Expected 1 pending notification, got %d", n.Pending())
+	}
+
+	td.updates = 0
+	tick(0)
+	checkLine(0, "                ")
+	checkLine(1, " AP DISENGAGED  ")
+	checkLine(2, "                ")
+	if !ti.blink || td.updates != 1 {
+		t.Errorf("Expected blink and 1 update, got %v and %d", ti.blink, td.updates)
+	}
+
+	// Regular contents are recorded, but not written, while a notification
+	// is shown
+	n.SetMFDText(1, []byte("ALT 5500"))
+	checkLine(1, " AP DISENGAGED  ")
+
+	td.updates = 0
+	tick(1000)
+	if td.updates != 0 {
+		t.Errorf("Expected no updates, got %d", td.updates)
+	}
+
+	tick(2000)
+	checkLine(0, "HDG 270")
+	checkLine(1, "ALT 5500")
+	if ti.blink || !ti.shift {
+		t.Errorf("Expected indicators to be restored, got blink %v, shift %v", ti.blink, ti.shift)
+	}
+	if n.Pending() != 0 {
+		t.Errorf("Expected no pending notifications, got %d", n.Pending())
+	}
+}
+
+func TestNotifierPriority(t *testing.T) {
+	td := &testDisplay{}
+	n := NewNotifier(td, nil)
+
+	base := time.Unix(1600000000, 0)
+	tick := func(ms int, expected string) {
+		t.Helper()
+		if err := n.Tick(base.Add(time.Duration(ms) * time.Millisecond)); err != nil {
+			t.Fatal("Unexpected error", err)
+		}
+		if got := string(bytes.TrimSpace(td.lines[1])); got != expected {
+			t.Errorf("At %dms: expected %q, got %q", ms, expected, got)
+		}
+	}
+
+	n.SetMFDText(1, []byte("BASE"))
+	n.Notify(Notification{Text: "LOW", Duration: time.Second})
+	n.Notify(Notification{Text: "LOW2", Duration: time.Second})
+	tick(0, "LOW")
+
+	// A higher priority message interrupts, and the rest of the lower
+	// priority message is shown afterwards
+	n.Notify(Notification{Text: "HIGH", Duration: time.Second, Priority: 1})
+	tick(400, "HIGH")
+	tick(1399, "HIGH")
+	tick(1400, "LOW")
+	tick(1999, "LOW")
+	tick(2000, "LOW2")
+
+	n.Dismiss()
+	tick(2100, "BASE")
+	if n.Pending() != 0 {
+		t.Errorf("Expected no pending notifications, got %d", n.Pending())
+	}
+}
+
+func TestNotifierShift(t *testing.T) {
+	td := &testDisplay{}
+	ti := &testIndicators{}
+	n := NewNotifier(td, ti)
+
+	base := time.Unix(1600000000, 0)
+	n.Notify(Notification{Text: "GEAR", Flags: NotifyShift})
+
+	for _, tc := range []struct {
+		ms    int
+		shift bool
+	}{
+		{0, true},
+		{499, true},
+		{500, false},
+		{1000, true},
+		{2999, false},
+		{3000, false},
+	} {
+		if err := n.Tick(base.Add(time.Duration(tc.ms) * time.Millisecond)); err != nil {
+			t.Fatal("Unexpected error", err)
+		}
+		if ti.shift != tc.shift {
+			t.Errorf("At %dms: expected shift %v, got %v", tc.ms, tc.shift, ti.shift)
+		}
+	}
+
+	// The regular state cannot be changed while the shift is blinking
+	n.Notify(Notification{Text: "FLAPS", Flags: NotifyShift})
+	n.Tick(base.Add(4 * time.Second))
+	shifts := ti.shifts
+	n.SetShift(true)
+	if ti.shifts != shifts {
+		t.Error("Expected shift to be left to the notification")
+	}
+	n.Dismiss()
+	n.Tick(base.Add(5 * time.Second))
+	if !ti.shift {
+		t.Error("Expected shift to be restored")
+	}
+}
@@ -0,0 +1,221 @@
+package util
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Pager switches the MFD between several named pages, such as NAV, COMMS and
+// ENGINE. Each page binds a LineSource to every line of the MFD, and the
+// pager provides a LineSource for each line that follows the current page,
+// which can be bound to a ScrollManager. Pages are switched by calling Next,
+// Previous or SetPage, or automatically on a timer. It is safe to switch
+// pages while the lines are being advanced by a ScrollManager.
+type Pager struct {
+	mu       sync.Mutex
+	pages    []pagerPage
+	current  int
+	interval time.Duration
+	lastStep time.Time
+	started  bool
+}
+
+// pagerPage is a single named page of a Pager
+type pagerPage struct {
+	name  string
+	lines [mfdLines]LineSource
+}
+
+// NewPager returns a Pager with no pages
+func NewPager() *Pager {
+	return &Pager{}
+}
+
+// AddPage adds a page with the given name after the existing pages. The lines
+// are bound to the lines of the MFD in order, and any line without a source
+// is blank. The first page added becomes the current page.
+func (p *Pager) AddPage(name string, lines ...LineSource) error {
+	if len(lines) > mfdLines {
+		return errors.New("too many lines for page")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.find(name) >= 0 {
+		return errors.New("duplicate page name")
+	}
+
+	page := pagerPage{name: name}
+	copy(page.lines[:], lines)
+	p.pages = append(p.pages, page)
+	return nil
+}
+
+// RemovePage removes the page with the given name. If it is the current page,
+// then the following page becomes the current page, wrapping around from the
+// last page to the first.
+func (p *Pager) RemovePage(name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	idx := p.find(name)
+	if idx < 0 {
+		return errors.New("page not found")
+	}
+
+	p.pages = append(p.pages[:idx], p.pages[idx+1:]...)
+	if p.current > idx {
+		p.current--
+	}
+	if p.current >= len(p.pages) {
+		p.current = 0
+	}
+	return nil
+}
+
+// Pages returns the names of the pages in order
+func (p *Pager) Pages() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	names := make([]string, len(p.pages))
+	for i, page := range p.pages {
+		names[i] = page.name
+	}
+	return names
+}
+
+// Current returns the name of the current page, or an empty string if there
+// are no pages. Applications can save the name and restore the page later
+// with SetPage.
+func (p *Pager) Current() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.pages) == 0 {
+		return ""
+	}
+	return p.pages[p.current].name
+}
+
+// SetPage switches to the page with the given name, and restarts the interval
+// used to switch pages automatically
+func (p *Pager) SetPage(name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	idx := p.find(name)
+	if idx < 0 {
+		return errors.New("page not found")
+	}
+
+	p.current = idx
+	p.started = false
+	return nil
+}
+
+// Next switches to the following page, wrapping around from the last page to
+// the first, and restarts the interval used to switch pages automatically
+func (p *Pager) Next() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.step(1)
+	p.started = false
+}
+
+// Previous switches to the preceding page, wrapping around from the first
+// page to the last, and restarts the interval used to switch pages
+// automatically
+func (p *Pager) Previous() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.step(-1)
+	p.started = false
+}
+
+// SetInterval sets the time after which the pager switches to the next page
+// automatically. An interval of 0 disables automatic switching.
+func (p *Pager) SetInterval(interval time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.interval = interval
+	p.started = false
+}
+
+// Line returns a LineSource for a single line of the MFD, which advances the
+// pager and then the source bound to that line of the current page. It
+// returns an error if the line is not on the MFD.
+func (p *Pager) Line(line int) (LineSource, error) {
+	if line < 0 || line >= mfdLines {
+		return nil, errors.New("line number out of range")
+	}
+
+	return pagerLine{p, line}, nil
+}
+
+// advance switches pages for every interval that has passed since the
+// previous switch, and returns the source for the line of the current page
+func (p *Pager) advance(now time.Time, line int) LineSource {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.interval > 0 {
+		if !p.started {
+			p.lastStep = now
+			p.started = true
+		}
+
+		for now.Sub(p.lastStep) >= p.interval {
+			p.lastStep = p.lastStep.Add(p.interval)
+			p.step(1)
+		}
+	}
+
+	if len(p.pages) == 0 {
+		return nil
+	}
+	return p.pages[p.current].lines[line]
+}
+
+// find returns the index of the page with the given name, or -1 if there is
+// no such page
+func (p *Pager) find(name string) int {
+	for i, page := range p.pages {
+		if page.name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// step moves to a page relative to the current page
+func (p *Pager) step(dir int) {
+	n := len(p.pages)
+	if n == 0 {
+		return
+	}
+
+	p.current = (p.current + dir + n) % n
+}
+
+// pagerLine is a LineSource for a single line of a Pager
+type pagerLine struct {
+	p    *Pager
+	line int
+}
+
+// Advance advances the pager and returns the line of the current page
+func (pl pagerLine) Advance(now time.Time) []byte {
+	src := pl.p.advance(now, pl.line)
+	if src == nil {
+		return nil
+	}
+
+	return src.Advance(now)
+}
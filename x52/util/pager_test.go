@@ -0,0 +1,137 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPager(t *testing.T) {
+	p := NewPager()
+	if p.Current() != "" {
+		t.Errorf("Expected no current page, got %q", p.Current())
+	}
+	for _, line := range []int{-1, mfdLines} {
+		if _, err := p.Line(line); err == nil {
+			t.Errorf("Line %v: expected error", line)
+		}
+	}
+
+	p.AddPage("NAV", StaticLine("HDG 270"), StaticLine("ALT 5000"))
+	p.AddPage("COMMS", StaticLine("COM1 118.300"))
+	p.AddPage("ENGINE", StaticLine("RPM 2400"))
+
+	if err := p.AddPage("NAV"); err == nil {
+		t.Error("Expected error for duplicate page name")
+	}
+	if err := p.AddPage("MANY", StaticLine(""), StaticLine(""), StaticLine(""), StaticLine("")); err == nil {
+		t.Error("Expected error for too many lines")
+	}
+
+	if names := p.Pages(); !reflect.DeepEqual(names, []string{"NAV", "COMMS", "ENGINE"}) {
+		t.Errorf("Unexpected pages %v", names)
+	}
+
+	now := time.Unix(1600000000, 0)
+	lines := func() []string {
+		var out []string
+		for i := 0; i < mfdLines; i++ {
+			src, err := p.Line(i)
+			if err != nil {
+				t.Fatalf("Line %v: unexpected error %v", i, err)
+			}
+			out = append(out, string(src.Advance(now)))
+		}
+		return out
+	}
+
+	if got := lines(); !reflect.DeepEqual(got, []string{"HDG 270", "ALT 5000", ""}) {
+		t.Errorf("Unexpected lines %q", got)
+	}
+
+	p.Next()
+	if p.Current() != "COMMS" {
+		t.Errorf("Expected COMMS, got %q", p.Current())
+	}
+	if got := lines(); !reflect.DeepEqual(got, []string{"COM1 118.300", "", ""}) {
+		t.Errorf("Unexpected lines %q", got)
+	}
+
+	p.Previous()
+	p.Previous()
+	if p.Current() != "ENGINE" {
+		t.Errorf("Expected wrap to ENGINE, got %q", p.Current())
+	}
+
+	if err := p.SetPage("COMMS"); err != nil || p.Current() != "COMMS" {
+		t.Errorf("Expected COMMS, got %q, error %v", p.Current(), err)
+	}
+	if err := p.SetPage("RADAR"); err == nil {
+		t.Error("Expected error for unknown page")
+	}
+
+	// Removing the current page moves to the following page
+	p.RemovePage("COMMS")
+	if p.Current() != "ENGINE" {
+		t.Errorf("Expected ENGINE after removal, got %q", p.Current())
+	}
+	p.RemovePage("ENGINE")
+	if p.Current() != "NAV" {
+		t.Errorf("Expected NAV after removal, got %q", p.Current())
+	}
+	if err := p.RemovePage("ENGINE"); err == nil {
+		t.Error("Expected error removing a missing page")
+	}
+
+	// Removing the last page wraps around to the first page
+	p.AddPage("COMMS")
+	p.AddPage("ENGINE")
+	p.SetPage("ENGINE")
+	p.RemovePage("ENGINE")
+	if p.Current() != "NAV" {
+		t.Errorf("Expected wrap to NAV after removal, got %q", p.Current())
+	}
+}
+
+func TestPagerInterval(t *testing.T) {
+	p := NewPager()
+	p.AddPage("A", StaticLine("A"))
+	p.AddPage("B", StaticLine("B"))
+	p.AddPage("C", StaticLine("C"))
+	p.SetInterval(5 * time.Second)
+
+	line, err := p.Line(0)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	base := time.Unix(1600000000, 0)
+	for _, tc := range []struct {
+		sec      int
+		expected string
+	}{
+		{0, "A"},
+		{4, "A"},
+		{5, "B"},
+		{9, "B"},
+		{10, "C"},
+		{15, "A"},
+	} {
+		got := line.Advance(base.Add(time.Duration(tc.sec) * time.Second))
+		if string(got) != tc.expected {
+			t.Errorf("At %ds: expected %q, got %q", tc.sec, tc.expected, got)
+		}
+	}
+
+	// Switching pages manually restarts the interval
+	p.Next()
+	if got := line.Advance(base.Add(24 * time.Second)); string(got) != "B" {
+		t.Errorf("Expected B after Next, got %q", got)
+	}
+	if got := line.Advance(base.Add(28 * time.Second)); string(got) != "B" {
+		t.Errorf("Expected B before the interval, got %q", got)
+	}
+	if got := line.Advance(base.Add(29 * time.Second)); string(got) != "C" {
+		t.Errorf("Expected C after the interval, got %q", got)
+	}
+}
@@ -0,0 +1,217 @@
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+)
+
+// RenderOptions control how the contents of the MFD are rendered to an image
+type RenderOptions struct {
+	// Scale is the size in output pixels of a single pixel of the MFD. If it
+	// is not set, then DefaultRenderScale is used.
+	Scale int
+
+	// Foreground and Background are the colors of the lit pixels and the
+	// background of the display. If they are not set, then
+	// DefaultRenderForeground and DefaultRenderBackground are used.
+	Foreground color.Color
+	Background color.Color
+
+	// LEDs is an optional list of LEDs, which are drawn below the MFD in
+	// the order given
+	LEDs []RenderLED
+}
+
+// RenderLED describes the state of a single LED for rendering
+type RenderLED struct {
+	// Name is the label drawn next to the LED
+	Name string
+
+	// Color is the color of the LED, or nil if the LED is off
+	Color color.Color
+}
+
+// DefaultRenderScale is the scale used if RenderOptions.Scale is not set
+const DefaultRenderScale = 4
+
+// Default colors of the rendered MFD
+var (
+	DefaultRenderForeground color.Color = color.RGBA{0x1a, 0x24, 0x10, 0xff}
+	DefaultRenderBackground color.Color = color.RGBA{0x9c, 0xc4, 0x4a, 0xff}
+)
+
+// Layout of the rendered display, in MFD pixels
+const (
+	renderBorder  = 2
+	renderGap     = 1
+	renderLEDSize = 5
+	renderSpacing = 4
+
+	renderWidth  = 2*renderBorder + mfdLineSize*GlyphWidth + (mfdLineSize-1)*renderGap
+	renderHeight = 2*renderBorder + mfdLines*GlyphHeight + (mfdLines-1)*renderGap
+)
+
+// renderRect is a filled rectangle in MFD pixels
+type renderRect struct {
+	x, y, w, h int
+	c          color.Color
+}
+
+// RenderImage renders the given lines of text, which must be in the code page
+// of the MFD display, to an image. Only the first 3 lines and the first 16
+// bytes of each line are drawn, and missing lines or bytes are left blank.
+func RenderImage(lines [][]byte, opts *RenderOptions) *image.RGBA {
+	o := renderDefaults(opts)
+	width, height, rects := renderLayout(lines, o)
+
+	img := image.NewRGBA(image.Rect(0, 0, width*o.Scale, height*o.Scale))
+	for _, r := range rects {
+		rect := image.Rect(r.x*o.Scale, r.y*o.Scale, (r.x+r.w)*o.Scale, (r.y+r.h)*o.Scale)
+		draw.Draw(img, rect, image.NewUniform(r.c), image.Point{}, draw.Src)
+	}
+
+	return img
+}
+
+// RenderPNG renders the given lines of text in the same way as RenderImage,
+// and writes the result to w in PNG format
+func RenderPNG(w io.Writer, lines [][]byte, opts *RenderOptions) error {
+	return png.Encode(w, RenderImage(lines, opts))
+}
+
+// RenderSVG renders the given lines of text in the same way as RenderImage,
+// and writes the result to w in SVG format
+func RenderSVG(w io.Writer, lines [][]byte, opts *RenderOptions) error {
+	o := renderDefaults(opts)
+	width, height, rects := renderLayout(lines, o)
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+"\n",
+		width*o.Scale, height*o.Scale, width, height)
+	for _, r := range rects {
+		fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" %s/>`+"\n",
+			r.x, r.y, r.w, r.h, svgFill(r.c))
+	}
+	fmt.Fprintln(bw, "</svg>")
+
+	return bw.Flush()
+}
+
+// renderDefaults returns a copy of the options with defaults filled in
+func renderDefaults(opts *RenderOptions) RenderOptions {
+	var o RenderOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	if o.Scale <= 0 {
+		o.Scale = DefaultRenderScale
+	}
+	if o.Foreground == nil {
+		o.Foreground = DefaultRenderForeground
+	}
+	if o.Background == nil {
+		o.Background = DefaultRenderBackground
+	}
+
+	return o
+}
+
+// renderLayout returns the size of the rendered display in MFD pixels, and
+// the list of rectangles to draw, starting with the background
+func renderLayout(lines [][]byte, o RenderOptions) (int, int, []renderRect) {
+	width, height := renderWidth, renderHeight
+
+	// Lay out the LEDs in rows below the display, starting a new row when
+	// the next LED and its label will not fit in the current row
+	type ledPos struct {
+		x, y  int
+		led   RenderLED
+		label []byte
+	}
+	var leds []ledPos
+	x, y := renderBorder, renderHeight
+	for _, led := range o.LEDs {
+		label := ConvertString(led.Name, ConvertReplace, ReplaceMissing)
+		w := renderLEDSize + 2*renderGap + len(label)*(GlyphWidth+renderGap)
+		if x != renderBorder && x+w > width-renderBorder {
+			x = renderBorder
+			y += GlyphHeight + renderGap
+		}
+
+		leds = append(leds, ledPos{x, y, led, label})
+		x += w + renderSpacing
+	}
+	if len(leds) != 0 {
+		height = y + GlyphHeight + renderBorder
+	}
+
+	rects := []renderRect{{0, 0, width, height, o.Background}}
+
+	for i := 0; i < mfdLines && i < len(lines); i++ {
+		y := renderBorder + i*(GlyphHeight+renderGap)
+		for j := 0; j < mfdLineSize && j < len(lines[i]); j++ {
+			x := renderBorder + j*(GlyphWidth+renderGap)
+			rects = renderGlyph(rects, x, y, lines[i][j], o.Foreground)
+		}
+	}
+
+	for _, l := range leds {
+		// The LED is drawn level with the capital letters of the label,
+		// filled if it is on, and as an outline if it is off
+		top := l.y + 1
+		if l.led.Color != nil {
+			rects = append(rects, renderRect{l.x, top, renderLEDSize, renderLEDSize, l.led.Color})
+		} else {
+			rects = append(rects,
+				renderRect{l.x, top, renderLEDSize, 1, o.Foreground},
+				renderRect{l.x, top + renderLEDSize - 1, renderLEDSize, 1, o.Foreground},
+				renderRect{l.x, top + 1, 1, renderLEDSize - 2, o.Foreground},
+				renderRect{l.x + renderLEDSize - 1, top + 1, 1, renderLEDSize - 2, o.Foreground},
+			)
+		}
+
+		x := l.x + renderLEDSize + 2*renderGap
+		for _, c := range l.label {
+			rects = renderGlyph(rects, x, l.y, c, o.Foreground)
+			x += GlyphWidth + renderGap
+		}
+	}
+
+	return width, height, rects
+}
+
+// renderGlyph appends the lit pixels of the glyph at the given position,
+// merging adjacent pixels in each row into a single rectangle
+func renderGlyph(rects []renderRect, x, y int, c byte, fg color.Color) []renderRect {
+	for row, bits := range Glyph(c) {
+		start := -1
+		for col := 0; col <= GlyphWidth; col++ {
+			lit := col < GlyphWidth && bits&(1<<(GlyphWidth-1-col)) != 0
+			if lit && start < 0 {
+				start = col
+			} else if !lit && start >= 0 {
+				rects = append(rects, renderRect{x + start, y + row, col - start, 1, fg})
+				start = -1
+			}
+		}
+	}
+
+	return rects
+}
+
+// svgFill returns the SVG fill attributes for the color
+func svgFill(c color.Color) string {
+	rgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+	fill := fmt.Sprintf(`fill="#%02x%02x%02x"`, rgba.R, rgba.G, rgba.B)
+	if rgba.A != 0xff {
+		fill += fmt.Sprintf(` fill-opacity="%.3f"`, float64(rgba.A)/0xff)
+	}
+
+	return fill
+}
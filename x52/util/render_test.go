@@ -0,0 +1,159 @@
+package util
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestRenderImage(t *testing.T) {
+	lines := [][]byte{[]byte("A"), nil, []byte("               .")}
+	img := RenderImage(lines, &RenderOptions{Scale: 1})
+
+	if b := img.Bounds(); b.Dx() != renderWidth || b.Dy() != renderHeight {
+		t.Fatalf("Unexpected image size %v", b)
+	}
+
+	// Check the pixels of the first glyph against the font
+	for row, bits := range Glyph('A') {
+		for col := 0; col < GlyphWidth; col++ {
+			exp := DefaultRenderBackground
+			if bits&(1<<(GlyphWidth-1-col)) != 0 {
+				exp = DefaultRenderForeground
+			}
+
+			got := img.At(renderBorder+col, renderBorder+row)
+			if !colorEqual(got, exp) {
+				t.Errorf("Pixel (%d, %d) mismatch, expected %v, got %v", col, row, exp, got)
+			}
+		}
+	}
+
+	// The period in the last column of the last line is drawn in the bottom
+	// right corner of the display
+	x := renderBorder + 15*(GlyphWidth+renderGap) + 1
+	y := renderBorder + 2*(GlyphHeight+renderGap) + 6
+	if !colorEqual(img.At(x, y), DefaultRenderForeground) {
+		t.Errorf("Expected lit pixel at (%d, %d)", x, y)
+	}
+
+	scaled := RenderImage(lines, &RenderOptions{Scale: 3})
+	if b := scaled.Bounds(); b.Dx() != 3*renderWidth || b.Dy() != 3*renderHeight {
+		t.Errorf("Unexpected scaled image size %v", b)
+	}
+}
+
+func TestRenderLEDs(t *testing.T) {
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	opts := &RenderOptions{
+		Scale: 1,
+		LEDs: []RenderLED{
+			{"FIRE", red},
+			{"A", nil},
+			{"THROTTLE", red},
+		},
+	}
+
+	img := RenderImage(nil, opts)
+	if img.Bounds().Dx() != renderWidth {
+		t.Errorf("Unexpected image width %d", img.Bounds().Dx())
+	}
+
+	// Two rows of LEDs below the display
+	if exp := renderHeight + 2*GlyphHeight + renderGap + renderBorder; img.Bounds().Dy() != exp {
+		t.Errorf("Expected image height %d, got %d", exp, img.Bounds().Dy())
+	}
+
+	// The first LED is filled, the second is drawn as an outline
+	if got := img.At(renderBorder+2, renderHeight+3); !colorEqual(got, red) {
+		t.Errorf("Expected lit LED, got %v", got)
+	}
+
+	x := renderBorder + renderLEDSize + 2*renderGap + 4*(GlyphWidth+renderGap) + renderSpacing
+	if got := img.At(x, renderHeight+1); !colorEqual(got, DefaultRenderForeground) {
+		t.Errorf("Expected LED outline, got %v", got)
+	}
+	if got := img.At(x+2, renderHeight+3); !colorEqual(got, DefaultRenderBackground) {
+		t.Errorf("Expected unlit LED, got %v", got)
+	}
+}
+
+func TestRenderPNG(t *testing.T) {
+	lines := [][]byte{ConvertString("Hello, World!", 0, 0)}
+
+	var buf bytes.Buffer
+	if err := RenderPNG(&buf, lines, nil); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal("Unable to decode PNG", err)
+	}
+
+	exp := RenderImage(lines, nil)
+	if img.Bounds() != exp.Bounds() {
+		t.Fatalf("Size mismatch, expected %v, got %v", exp.Bounds(), img.Bounds())
+	}
+	for y := 0; y < exp.Bounds().Dy(); y++ {
+		for x := 0; x < exp.Bounds().Dx(); x++ {
+			if !colorEqual(img.At(x, y), exp.At(x, y)) {
+				t.Fatalf("Pixel mismatch at (%d, %d)", x, y)
+			}
+		}
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	var buf bytes.Buffer
+	opts := &RenderOptions{
+		Scale:      2,
+		Background: color.NRGBA{0, 0, 0, 0x80},
+		LEDs:       []RenderLED{{"A", color.RGBA{0, 0xff, 0, 0xff}}},
+	}
+	if err := RenderSVG(&buf, [][]byte{[]byte("-")}, opts); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	var svg struct {
+		Width   int    `xml:"width,attr"`
+		ViewBox string `xml:"viewBox,attr"`
+		Rects   []struct {
+			X       int    `xml:"x,attr"`
+			Width   int    `xml:"width,attr"`
+			Fill    string `xml:"fill,attr"`
+			Opacity string `xml:"fill-opacity,attr"`
+		} `xml:"rect"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &svg); err != nil {
+		t.Fatal("Invalid SVG", err)
+	}
+
+	if svg.Width != 2*renderWidth || !strings.HasPrefix(svg.ViewBox, "0 0 99 ") {
+		t.Errorf("Unexpected size %d, viewBox %q", svg.Width, svg.ViewBox)
+	}
+
+	// Background, the dash, the LED and the label A (7 rows, the middle
+	// row is a single run, the others are split around the counter)
+	if len(svg.Rects) != 1+1+1+12 {
+		t.Fatalf("Unexpected number of rectangles %d", len(svg.Rects))
+	}
+	if r := svg.Rects[0]; r.Fill != "#000000" || r.Opacity != "0.502" {
+		t.Errorf("Unexpected background %+v", r)
+	}
+	if r := svg.Rects[1]; r.X != renderBorder || r.Width != GlyphWidth || r.Fill != "#1a2410" {
+		t.Errorf("Unexpected dash %+v", r)
+	}
+	if r := svg.Rects[2]; r.Fill != "#00ff00" {
+		t.Errorf("Unexpected LED %+v", r)
+	}
+}
+
+func colorEqual(a, b color.Color) bool {
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	return ar == br && ag == bg && ab == bb && aa == ba
+}
@@ -0,0 +1,170 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// Line size on each of the MFDs
+	mfdLineSize = 16
+
+	// Number of lines
+	mfdLines = 3
+)
+
+// Display is the subset of the X52 context API used to write to the MFD. It
+// is satisfied by *x52.Context.
+type Display interface {
+	SetMFDText(line uint8, data []byte) error
+	Update() error
+}
+
+// Screen is a framebuffer for the 3x16 character MFD. Text is written at a
+// cursor position, and only the lines that have changed since the last
+// update are written to the display.
+type Screen struct {
+	display Display
+	lines   [mfdLines][mfdLineSize]byte
+	written [mfdLines][mfdLineSize]byte
+	flushed bool
+	replace bool
+	repl    byte
+	line    int
+	col     int
+}
+
+// NewScreen returns a blank Screen that writes to the given display. Runes
+// that are not in the MFD code page are replaced with ReplaceMissing.
+func NewScreen(display Display) *Screen {
+	screen := &Screen{
+		display: display,
+		replace: true,
+		repl:    ReplaceMissing,
+	}
+
+	screen.Clear()
+
+	return screen
+}
+
+// SetReplacement controls how runes that are not in the MFD code page are
+// handled. If replace is true, they are replaced with the byte c, otherwise
+// they are dropped.
+func (sc *Screen) SetReplacement(replace bool, c byte) {
+	sc.replace = replace
+	sc.repl = c
+}
+
+// Clear blanks the screen and moves the cursor to the top left
+func (sc *Screen) Clear() {
+	for i := range sc.lines {
+		copy(sc.lines[i][:], bytes.Repeat([]byte{0x20}, mfdLineSize))
+	}
+
+	sc.line = 0
+	sc.col = 0
+}
+
+// MoveTo moves the cursor to the given line and column, both of which start
+// from 0
+func (sc *Screen) MoveTo(line, col int) error {
+	if line < 0 || line >= mfdLines {
+		return errors.New("line number out of range")
+	}
+	if col < 0 || col >= mfdLineSize {
+		return errors.New("column number out of range")
+	}
+
+	sc.line = line
+	sc.col = col
+	return nil
+}
+
+// Cursor returns the current line and column of the cursor
+func (sc *Screen) Cursor() (line, col int) {
+	return sc.line, sc.col
+}
+
+// Print writes the string at the cursor, and advances the cursor. A newline
+// moves the cursor to the start of the next line. Text beyond the end of a
+// line, or below the last line, is discarded.
+func (sc *Screen) Print(s string) {
+	for i, segment := range strings.Split(s, "\n") {
+		if i != 0 {
+			sc.line++
+			sc.col = 0
+		}
+
+		if sc.line >= mfdLines {
+			// Keep the cursor on the screen
+			sc.line = mfdLines - 1
+			sc.col = mfdLineSize
+			return
+		}
+
+		data := ConvertStringToX52Charmap(segment, sc.replace, sc.repl)
+		sc.col += copy(sc.lines[sc.line][sc.col:], data)
+	}
+}
+
+// Printf formats according to a format specifier and writes the result at
+// the cursor
+func (sc *Screen) Printf(format string, a ...interface{}) {
+	sc.Print(fmt.Sprintf(format, a...))
+}
+
+// WriteAt moves the cursor to the given line and column and writes the
+// string there
+func (sc *Screen) WriteAt(line, col int, s string) error {
+	if err := sc.MoveTo(line, col); err != nil {
+		return err
+	}
+
+	sc.Print(s)
+	return nil
+}
+
+// Line returns the contents of the given line in the MFD code page
+func (sc *Screen) Line(line int) []byte {
+	if line < 0 || line >= mfdLines {
+		return nil
+	}
+
+	return append([]byte(nil), sc.lines[line][:]...)
+}
+
+// put copies data, which must already be in the MFD code page, to the given
+// position without moving the cursor. Data beyond the end of the line is
+// discarded.
+func (sc *Screen) put(line, col int, data []byte) {
+	if line < 0 || line >= mfdLines || col < 0 || col >= mfdLineSize {
+		return
+	}
+
+	copy(sc.lines[line][col:], data)
+}
+
+// Update writes the lines that have changed since the previous update to the
+// display, and then updates the display
+func (sc *Screen) Update() error {
+	for i := range sc.lines {
+		if sc.flushed && sc.lines[i] == sc.written[i] {
+			continue
+		}
+
+		if err := sc.display.SetMFDText(uint8(i), sc.Line(i)); err != nil {
+			return err
+		}
+		sc.written[i] = sc.lines[i]
+	}
+
+	if err := sc.display.Update(); err != nil {
+		return err
+	}
+
+	sc.flushed = true
+	return nil
+}
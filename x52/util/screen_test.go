@@ -0,0 +1,125 @@
+package util
+
+import (
+	"bytes"
+	"testing"
+)
+
+// testDisplay records the calls made through the Display interface
+type testDisplay struct {
+	lines   [mfdLines][]byte
+	writes  []uint8
+	updates int
+}
+
+func (td *testDisplay) SetMFDText(line uint8, data []byte) error {
+	td.lines[line] = data
+	td.writes = append(td.writes, line)
+	return nil
+}
+
+func (td *testDisplay) Update() error {
+	td.updates++
+	return nil
+}
+
+func TestScreenPrint(t *testing.T) {
+	sc := NewScreen(&testDisplay{})
+
+	sc.Printf("%s %d", "Alt", 12000)
+	sc.Print("\nLong line that does not fit\nü\\")
+
+	expected := [][]byte{
+		[]byte("Alt 12000       "),
+		[]byte("Long line that d"),
+		[]byte{0x81, ReplaceMissing, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+			0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20},
+	}
+
+	for i, exp := range expected {
+		if got := sc.Line(i); !bytes.Equal(got, exp) {
+			t.Errorf("Line %d mismatch, expected %q, got %q", i, exp, got)
+		}
+	}
+
+	if line, col := sc.Cursor(); line != 2 || col != 2 {
+		t.Errorf("Cursor mismatch, expected (2, 2), got (%d, %d)", line, col)
+	}
+
+	// Text beyond the last line is discarded
+	sc.Print("\n\nfoo")
+	if got := sc.Line(2); !bytes.Equal(got[:2], expected[2][:2]) {
+		t.Errorf("Last line overwritten, got %q", got)
+	}
+}
+
+func TestScreenWriteAt(t *testing.T) {
+	sc := NewScreen(&testDisplay{})
+
+	if err := sc.WriteAt(1, 12, "ABCDEF"); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	if got, exp := sc.Line(1), []byte("            ABCD"); !bytes.Equal(got, exp) {
+		t.Errorf("Line mismatch, expected %q, got %q", exp, got)
+	}
+
+	tests := []struct {
+		line, col int
+	}{
+		{-1, 0},
+		{3, 0},
+		{0, -1},
+		{0, 16},
+	}
+	for _, tc := range tests {
+		if err := sc.WriteAt(tc.line, tc.col, "x"); err == nil {
+			t.Errorf("Expected error writing at (%d, %d)", tc.line, tc.col)
+		}
+	}
+
+	sc.Clear()
+	if got, exp := sc.Line(1), bytes.Repeat([]byte{0x20}, 16); !bytes.Equal(got, exp) {
+		t.Errorf("Line not cleared, got %q", got)
+	}
+	if line, col := sc.Cursor(); line != 0 || col != 0 {
+		t.Errorf("Cursor not reset, got (%d, %d)", line, col)
+	}
+}
+
+func TestScreenUpdate(t *testing.T) {
+	td := &testDisplay{}
+	sc := NewScreen(td)
+
+	check := func(writes []uint8, updates int) {
+		t.Helper()
+		if !bytes.Equal(td.writes, writes) || td.updates != updates {
+			t.Errorf("Expected writes %v, updates %d, got writes %v, updates %d",
+				writes, updates, td.writes, td.updates)
+		}
+		td.writes = nil
+	}
+
+	// The first update writes every line
+	if err := sc.Update(); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	check([]uint8{0, 1, 2}, 1)
+
+	// Only changed lines are written
+	sc.WriteAt(1, 0, "Hello")
+	sc.Update()
+	check([]uint8{1}, 2)
+
+	sc.Update()
+	check(nil, 3)
+
+	// Rewriting the same text is not a change
+	sc.WriteAt(1, 0, "Hello")
+	sc.WriteAt(2, 0, "World")
+	sc.Update()
+	check([]uint8{2}, 4)
+
+	if got, exp := td.lines[2], []byte("World           "); !bytes.Equal(got, exp) {
+		t.Errorf("Display line mismatch, expected %q, got %q", exp, got)
+	}
+}
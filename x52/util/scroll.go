@@ -3,6 +3,7 @@ package util
 import (
 	"bytes"
 	"errors"
+	"time"
 )
 
 // Scroller allows the application to save a long string, and scroll it
@@ -12,10 +13,19 @@ type Scroller struct {
 	prefix  []byte
 	suffix  []byte
 	buffer  []byte
+	width   int
 	flags   ScrollFlags
 	textPos int
 	start   int
 	end     int
+	reverse bool
+
+	// Timing for Advance
+	speed      float64
+	dwellStart time.Duration
+	dwellEnd   time.Duration
+	epoch      time.Time
+	started    bool
 }
 
 // ScrollFlags control the behavior of the scroller
@@ -33,24 +43,90 @@ const (
 	// ScrollLeftToRight will scroll the text left-to-right instead of the
 	// default right-to-left
 	ScrollLeftToRight
+
+	// ScrollBounce will reverse the direction of the scroll at the end of
+	// the text, and scroll back to the start, instead of jumping back to
+	// the start
+	ScrollBounce
 )
 
+// DefaultScrollSpeed is the speed in characters per second used by Advance,
+// unless changed with SetSpeed
+const DefaultScrollSpeed = 4.0
+
 // NewScroller returns a Scroller with the given parameters setup. It may
 // return an error if the prefix and suffix combined will not leave sufficient
 // space for the text to be displayed. The text, prefix and suffix must be in
 // the code page of the MFD display.
 func NewScroller(text, prefix, suffix []byte, flags ScrollFlags) (*Scroller, error) {
+	return newScroller(text, prefix, suffix, flags, mfdLineSize)
+}
+
+// ScrollOptions control the conversion and size of a Scroller created with
+// NewScrollerString
+type ScrollOptions struct {
+	// Width is the number of characters used by the scroller, including
+	// the prefix and suffix. It defaults to the full width of the MFD, and
+	// smaller widths allow the scroller to be placed in part of a line.
+	Width int
+
+	// Convert and Replacement control the conversion of the strings to the
+	// code page of the MFD, as for ConvertString. If Replacement is not
+	// set, then ReplaceMissing is used.
+	Convert     ConvertFlags
+	Replacement byte
+
+	// Charmap is used for the conversion if set, otherwise the built-in
+	// character map is used
+	Charmap *Charmap
+}
+
+// NewScrollerString returns a Scroller for the given text, prefix and suffix,
+// which are converted to the code page of the MFD according to the options.
+// A nil options uses the defaults. It returns an error if the width is out of
+// range, or if the prefix and suffix use more than half of the width.
+func NewScrollerString(text, prefix, suffix string, flags ScrollFlags, opts *ScrollOptions) (*Scroller, error) {
+	var o ScrollOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	if o.Width == 0 {
+		o.Width = mfdLineSize
+	}
+	if o.Replacement == 0 {
+		o.Replacement = ReplaceMissing
+	}
+	cm := o.Charmap
+	if cm == nil {
+		cm = builtinCharmap
+	}
+
+	return newScroller(
+		cm.Convert(text, o.Convert, o.Replacement),
+		cm.Convert(prefix, o.Convert, o.Replacement),
+		cm.Convert(suffix, o.Convert, o.Replacement),
+		flags, o.Width)
+}
+
+// newScroller returns a Scroller that uses the given number of characters
+func newScroller(text, prefix, suffix []byte, flags ScrollFlags, width int) (*Scroller, error) {
+	if width < 1 || width > mfdLineSize {
+		return nil, errors.New("scroller width out of range")
+	}
+
+	// Maximum length of prefix and suffix combined is half the width,
+	// otherwise it won't leave enough space to allow for a reasonable scroll
+	if len(prefix)+len(suffix) > width/2 {
+		return nil, errors.New("prefix and suffix combined length too long")
+	}
+
 	scroller := &Scroller{
 		text:   text,
 		prefix: prefix,
 		suffix: suffix,
-		flags:  flags,
-	}
-
-	// Maximum length of prefix and suffix combined is 8, otherwise it won't
-	// leave enough space to allow for a reasonable scroll
-	if len(prefix)+len(suffix) > 8 {
-		return nil, errors.New("prefix and suffix combined length too long")
+		width:  width,
+		speed:  DefaultScrollSpeed,
 	}
 
 	scroller.SetFlags(flags)
@@ -58,9 +134,11 @@ func NewScroller(text, prefix, suffix []byte, flags ScrollFlags) (*Scroller, err
 	return scroller, nil
 }
 
-// Bytes returns a slice of bytes for passing to the MFD text API
+// Bytes returns a slice of bytes for passing to the MFD text API. The slice
+// has the width of the scroller, which is the full width of the MFD unless
+// set otherwise with NewScrollerString.
 func (sc *Scroller) Bytes() []byte {
-	var data = bytes.Repeat([]byte{0x20}, 16)
+	var data = bytes.Repeat([]byte{0x20}, sc.width)
 	var pos int
 	var tpos int
 
@@ -68,11 +146,11 @@ func (sc *Scroller) Bytes() []byte {
 		data[pos] = sc.prefix[pos]
 	}
 
-	for pos = 16 - len(sc.suffix); pos < 16; pos++ {
-		data[pos] = sc.suffix[pos-16+len(sc.suffix)]
+	for pos = sc.width - len(sc.suffix); pos < sc.width; pos++ {
+		data[pos] = sc.suffix[pos-sc.width+len(sc.suffix)]
 	}
 
-	for tpos, pos = 0, len(sc.prefix); pos < 16-len(sc.suffix); tpos, pos = tpos+1, pos+1 {
+	for tpos, pos = 0, len(sc.prefix); pos < sc.width-len(sc.suffix); tpos, pos = tpos+1, pos+1 {
 		if sc.textPos+tpos >= len(sc.buffer) {
 			break
 		}
@@ -86,27 +164,186 @@ func (sc *Scroller) Bytes() []byte {
 // Scroll scrolls the text by a single character and returns a slice of bytes
 // for passing to the MFD text API
 func (sc *Scroller) Scroll() []byte {
-	if sc.start != sc.end {
-		if sc.start < sc.end {
-			sc.textPos++
+	n := sc.positions()
+	pos := sc.position()
+
+	if sc.flags&ScrollBounce != 0 && n > 1 {
+		if sc.reverse {
+			pos--
 		} else {
-			sc.textPos--
+			pos++
 		}
+
+		if pos == 0 || pos == n-1 {
+			sc.reverse = !sc.reverse
+		}
+	} else {
+		pos = (pos + 1) % n
 	}
 
-	if sc.textPos == sc.end {
-		sc.textPos = sc.start
+	sc.setPosition(pos)
+	return sc.Bytes()
+}
+
+// Advance moves the text to the position it should be at the given time, and
+// returns a slice of bytes for passing to the MFD text API. The first call to
+// Advance after creating or resetting the scroller starts the scroll cycle.
+//
+// Each scroll cycle starts by showing the text in the starting position for
+// the start dwell time. The text then scrolls at the configured speed, and
+// stays in the final position for the end dwell time. If ScrollBounce is set,
+// the text then scrolls back to the starting position, otherwise it jumps
+// back to the starting position.
+func (sc *Scroller) Advance(now time.Time) []byte {
+	if !sc.started {
+		sc.epoch = now
+		sc.started = true
 	}
 
+	sc.setPosition(sc.positionAt(now.Sub(sc.epoch)))
 	return sc.Bytes()
 }
 
+// SetSpeed sets the speed used by Advance in characters per second. It
+// returns an error if the speed is not positive. The scroll cycle restarts at
+// the next call to Advance.
+func (sc *Scroller) SetSpeed(charsPerSecond float64) error {
+	if !(charsPerSecond > 0) {
+		return errors.New("scroll speed must be positive")
+	}
+
+	sc.speed = charsPerSecond
+	sc.Reset()
+	return nil
+}
+
+// SetDwell sets the time for which Advance holds the text at the start and
+// end positions of each scroll cycle. The scroll cycle restarts at the next
+// call to Advance.
+func (sc *Scroller) SetDwell(start, end time.Duration) {
+	if start < 0 {
+		start = 0
+	}
+	if end < 0 {
+		end = 0
+	}
+
+	sc.dwellStart = start
+	sc.dwellEnd = end
+	sc.Reset()
+}
+
+// positions returns the number of distinct positions in the scroll cycle
+func (sc *Scroller) positions() int {
+	if sc.start < sc.end {
+		return sc.end - sc.start
+	} else if sc.start > sc.end {
+		return sc.start - sc.end
+	}
+
+	return 1
+}
+
+// position returns the index of the current position in the scroll cycle
+func (sc *Scroller) position() int {
+	if sc.start > sc.end {
+		return sc.start - sc.textPos
+	}
+
+	return sc.textPos - sc.start
+}
+
+// setPosition moves the text to the given index in the scroll cycle
+func (sc *Scroller) setPosition(pos int) {
+	if sc.start > sc.end {
+		sc.textPos = sc.start - pos
+	} else {
+		sc.textPos = sc.start + pos
+	}
+}
+
+// positionAt returns the index of the position in the scroll cycle at the
+// given time since the start of the cycle
+func (sc *Scroller) positionAt(elapsed time.Duration) int {
+	n := sc.positions()
+	if n <= 1 || elapsed < 0 {
+		return 0
+	}
+
+	step := time.Duration(float64(time.Second) / sc.speed)
+	if step <= 0 {
+		step = 1
+	}
+
+	// Every position is shown for one step, with the dwell times added to
+	// the first and last positions. When bouncing, the positions between
+	// the first and last are also shown on the way back.
+	steps := n
+	if sc.flags&ScrollBounce != 0 {
+		steps = 2*n - 2
+	}
+	cycle := sc.dwellStart + sc.dwellEnd + time.Duration(steps)*step
+
+	t := elapsed % cycle
+	if t < sc.dwellStart+step {
+		return 0
+	}
+	t -= sc.dwellStart
+
+	// Scrolling towards the final position
+	if pos := int(t / step); pos < n-1 {
+		return pos
+	}
+	t -= time.Duration(n-1) * step
+
+	// Holding at the final position
+	if t < step+sc.dwellEnd {
+		return n - 1
+	}
+	t -= step + sc.dwellEnd
+
+	// Scrolling back towards the starting position
+	pos := n - 2 - int(t/step)
+	if pos < 1 {
+		pos = 1
+	}
+	return pos
+}
+
 // SetFlags updates the scroller flags
 func (sc *Scroller) SetFlags(flags ScrollFlags) {
-	defer sc.Reset()
-	// Add a buffer of 16-len(prefix)-len(suffix) spaces on both sides
+	sc.flags = flags
+	sc.layout()
+	sc.Reset()
+}
+
+// SetText replaces the text of the scroller, which must be in the code page
+// of the MFD display. Unlike creating a new Scroller, this does not restart
+// the scroll cycle, so Advance continues with the same timing, and Scroll
+// continues from the same position where possible.
+func (sc *Scroller) SetText(text []byte) {
+	pos := sc.position()
+	sc.text = text
+	sc.layout()
+
+	n := sc.positions()
+	if pos >= n-1 {
+		pos = n - 1
+		sc.reverse = n > 1
+	}
+	if pos == 0 {
+		sc.reverse = false
+	}
+	sc.setPosition(pos)
+}
+
+// layout builds the scroll buffer from the text and flags
+func (sc *Scroller) layout() {
+	flags := sc.flags
+
+	// Add a buffer of width-len(prefix)-len(suffix) spaces on both sides
 	// to allow for scrolling
-	buflen := 16 - len(sc.prefix) - len(sc.suffix)
+	buflen := sc.width - len(sc.prefix) - len(sc.suffix)
 	buffer := bytes.Repeat([]byte{0x20}, buflen)
 
 	sc.buffer = sc.text
@@ -140,7 +377,10 @@ func (sc *Scroller) SetFlags(flags ScrollFlags) {
 	}
 }
 
-// Reset resets the scroller to the default position
+// Reset resets the scroller to the default position, and restarts the scroll
+// cycle used by Advance
 func (sc *Scroller) Reset() {
 	sc.textPos = sc.start
+	sc.reverse = false
+	sc.started = false
 }
@@ -3,6 +3,7 @@ package util
 import (
 	"bytes"
 	"testing"
+	"time"
 )
 
 // This file tests the scroll functionality
@@ -1095,3 +1096,178 @@ func TestScrollerShortText(t *testing.T) {
 	testShort(nil, suf, []byte("foobar       <<<"), t)
 	testShort(nil, nil, []byte("foobar          "), t)
 }
+
+func TestScrollBounce(t *testing.T) {
+	scroller, _ := NewScroller([]byte("ABCDEFGHIJ"), []byte(">>> "), []byte(" <<<"),
+		ScrollBounce)
+
+	expected := [][]byte{
+		[]byte(">>> ABCDEFGH <<<"),
+		[]byte(">>> BCDEFGHI <<<"),
+		[]byte(">>> CDEFGHIJ <<<"),
+		[]byte(">>> BCDEFGHI <<<"),
+	}
+
+	testScroll(scroller, expected, t)
+}
+
+func TestScrollBounceLTR(t *testing.T) {
+	scroller, _ := NewScroller([]byte("ABCDEFGHIJKLMNOPQRS"), nil, nil,
+		ScrollBounce|ScrollLeftToRight)
+
+	expected := [][]byte{
+		[]byte("DEFGHIJKLMNOPQRS"),
+		[]byte("CDEFGHIJKLMNOPQR"),
+		[]byte("BCDEFGHIJKLMNOPQ"),
+		[]byte("ABCDEFGHIJKLMNOP"),
+		[]byte("BCDEFGHIJKLMNOPQ"),
+		[]byte("CDEFGHIJKLMNOPQR"),
+	}
+
+	testScroll(scroller, expected, t)
+}
+
+// testAdvance checks the output of Advance at the given times, in
+// milliseconds from the first call
+func testAdvance(sc *Scroller, times []int, expected []string, t *testing.T) {
+	base := time.Unix(1600000000, 0)
+	for i, ms := range times {
+		got := sc.Advance(base.Add(time.Duration(ms) * time.Millisecond))
+		if !bytes.Equal(got, []byte(expected[i])) {
+			t.Errorf("Advance at %dms:\n\texp: %q\n\tgot: %q", ms, expected[i], got)
+		}
+	}
+}
+
+func TestScrollAdvance(t *testing.T) {
+	scroller, _ := NewScroller([]byte("ABCDEFGHIJ"), []byte(">>> "), []byte(" <<<"), 0)
+	if err := scroller.SetSpeed(10); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	// Without dwell, each position is shown for 100ms, the same as
+	// calling Scroll every 100ms
+	testAdvance(scroller,
+		[]int{0, 99, 100, 250, 299, 300, 350, 400},
+		[]string{
+			">>> ABCDEFGH <<<",
+			">>> ABCDEFGH <<<",
+			">>> BCDEFGHI <<<",
+			">>> CDEFGHIJ <<<",
+			">>> CDEFGHIJ <<<",
+			">>> ABCDEFGH <<<",
+			">>> ABCDEFGH <<<",
+			">>> BCDEFGHI <<<",
+		}, t)
+
+	// Dwell of 1s at the start and 500ms at the end, for a cycle of 1.8s
+	scroller.SetDwell(time.Second, 500*time.Millisecond)
+	testAdvance(scroller,
+		[]int{0, 1099, 1100, 1200, 1799, 1800, 2899, 2900},
+		[]string{
+			">>> ABCDEFGH <<<",
+			">>> ABCDEFGH <<<",
+			">>> BCDEFGHI <<<",
+			">>> CDEFGHIJ <<<",
+			">>> CDEFGHIJ <<<",
+			">>> ABCDEFGH <<<",
+			">>> ABCDEFGH <<<",
+			">>> BCDEFGHI <<<",
+		}, t)
+}
+
+func TestScrollAdvanceBounce(t *testing.T) {
+	scroller, _ := NewScroller([]byte("ABCDEFGHIJK"), []byte(">>> "), []byte(" <<<"),
+		ScrollBounce)
+	scroller.SetSpeed(10)
+	scroller.SetDwell(200*time.Millisecond, 300*time.Millisecond)
+
+	// 4 positions, start dwell 200ms, end dwell 300ms, and 6 steps of
+	// 100ms for a cycle of 1.1s
+	testAdvance(scroller,
+		[]int{0, 299, 300, 400, 500, 899, 900, 1000, 1099, 1100, 1400},
+		[]string{
+			">>> ABCDEFGH <<<",
+			">>> ABCDEFGH <<<",
+			">>> BCDEFGHI <<<",
+			">>> CDEFGHIJ <<<",
+			">>> DEFGHIJK <<<",
+			">>> DEFGHIJK <<<",
+			">>> CDEFGHIJ <<<",
+			">>> BCDEFGHI <<<",
+			">>> BCDEFGHI <<<",
+			">>> ABCDEFGH <<<",
+			">>> BCDEFGHI <<<",
+		}, t)
+}
+
+func TestScrollAdvanceShortText(t *testing.T) {
+	scroller, _ := NewScroller([]byte("foobar"), nil, nil, ScrollBounce)
+	testAdvance(scroller, []int{0, 1000, 5000},
+		[]string{"foobar          ", "foobar          ", "foobar          "}, t)
+}
+
+func TestScrollSetSpeed(t *testing.T) {
+	scroller, _ := NewScroller([]byte("foobar"), nil, nil, 0)
+	for _, speed := range []float64{0, -1} {
+		if err := scroller.SetSpeed(speed); err == nil {
+			t.Errorf("Expected error setting speed %v", speed)
+		}
+	}
+}
+
+func TestScrollerString(t *testing.T) {
+	scroller, err := NewScrollerString("Zürich→Genève", "» ", "", 0,
+		&ScrollOptions{Width: 8, Convert: ConvertReplace | ConvertTransliterate})
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	expected := [][]byte{
+		{0xFC, ' ', 'Z', 0x81, 'r', 'i', 'c', 'h'},
+		{0xFC, ' ', 0x81, 'r', 'i', 'c', 'h', 0x7E},
+	}
+	for i, exp := range expected {
+		if got := scroller.Bytes(); !bytes.Equal(got, exp) {
+			t.Errorf("Step %d expected %#x, got %#x", i, exp, got)
+		}
+		scroller.Scroll()
+	}
+
+	// Defaults to the full width, with unknown runes dropped
+	scroller, err = NewScrollerString("☃ snow", "", "", 0, nil)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	exp := []byte(" snow           ")
+	if got := scroller.Bytes(); !bytes.Equal(got, exp) {
+		t.Errorf("Expected %q, got %q", exp, got)
+	}
+
+	// Custom charmap
+	cm := NewCharmap()
+	cm.Set('☃', '*')
+	scroller, _ = NewScrollerString("☃", "", "", 0, &ScrollOptions{Width: 1, Charmap: cm})
+	if got := scroller.Bytes(); !bytes.Equal(got, []byte("*")) {
+		t.Errorf("Expected custom charmap conversion, got %q", got)
+	}
+}
+
+func TestScrollerStringErrors(t *testing.T) {
+	tests := []struct {
+		prefix, suffix string
+		width          int
+	}{
+		{"", "", -1},
+		{"", "", 17},
+		{"ab", "cd", 6},
+		{">>>> ", " <<<<", 0},
+	}
+
+	for _, tc := range tests {
+		_, err := NewScrollerString("text", tc.prefix, tc.suffix, 0, &ScrollOptions{Width: tc.width})
+		if err == nil {
+			t.Errorf("Expected error for prefix %q, suffix %q, width %d", tc.prefix, tc.suffix, tc.width)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"time"
+)
+
+// LineSource provides the contents of an MFD line at a given time. It is
+// satisfied by *Scroller.
+type LineSource interface {
+	Advance(now time.Time) []byte
+}
+
+// StaticLine is a LineSource that always returns the same text, which must be
+// in the code page of the MFD display
+type StaticLine []byte
+
+// Advance returns the text of the line
+func (sl StaticLine) Advance(now time.Time) []byte {
+	return sl
+}
+
+// LineFields is a LineSource that places several sources side by side on a
+// single line, such as a fixed label followed by a narrow Scroller. Text
+// beyond the width of the MFD is discarded.
+type LineFields []LineSource
+
+// Advance advances every source, and returns the combined line
+func (lf LineFields) Advance(now time.Time) []byte {
+	var line []byte
+	for _, src := range lf {
+		line = append(line, src.Advance(now)...)
+	}
+
+	if len(line) > mfdLineSize {
+		line = line[:mfdLineSize]
+	}
+	return line
+}
+
+// ScrollManager binds a LineSource, such as a Scroller or static text, to each
+// line of the MFD, and advances all of them on a shared tick. Only the lines
+// that have changed are written to the display, and the display is updated
+// at most once per tick. It is safe to change the lines while Run is active.
+type ScrollManager struct {
+	display Display
+	mu      sync.Mutex
+	sources [mfdLines]LineSource
+	written [mfdLines][]byte
+	valid   [mfdLines]bool
+}
+
+// NewScrollManager returns a ScrollManager that writes to the given display,
+// with no lines bound
+func NewScrollManager(display Display) *ScrollManager {
+	return &ScrollManager{display: display}
+}
+
+// SetLine binds the source to the line, replacing any previous source. A nil
+// source unbinds the line, which leaves the text on the display unchanged.
+// The source must not be modified while bound, except through the manager.
+func (m *ScrollManager) SetLine(line int, src LineSource) error {
+	if line < 0 || line >= mfdLines {
+		return errors.New("line number out of range")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sources[line] = src
+	return nil
+}
+
+// SetText changes the text of the line, which must be in the code page of
+// the MFD display. If the line is bound to a Scroller, then the text of the
+// Scroller is replaced without restarting its scroll cycle, otherwise the
+// line is bound to the static text.
+func (m *ScrollManager) SetText(line int, text []byte) error {
+	if line < 0 || line >= mfdLines {
+		return errors.New("line number out of range")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if sc, ok := m.sources[line].(*Scroller); ok {
+		sc.SetText(text)
+	} else {
+		m.sources[line] = StaticLine(text)
+	}
+	return nil
+}
+
+// Tick advances every bound line to the given time, writes the lines that
+// have changed to the display, and updates the display if any line was
+// written
+func (m *ScrollManager) Tick(now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	changed := false
+	for i, src := range m.sources {
+		if src == nil {
+			continue
+		}
+
+		data := src.Advance(now)
+		if m.valid[i] && bytes.Equal(data, m.written[i]) {
+			continue
+		}
+
+		if err := m.display.SetMFDText(uint8(i), data); err != nil {
+			return err
+		}
+		m.written[i] = append(m.written[i][:0], data...)
+		m.valid[i] = true
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	return m.display.Update()
+}
+
+// Run calls Tick at the given interval until the stop channel is closed, or
+// Tick returns an error, which is then returned by Run
+func (m *ScrollManager) Run(interval time.Duration, stop <-chan struct{}) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	if err := m.Tick(time.Now()); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case now := <-ticker.C:
+			if err := m.Tick(now); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScrollManager(t *testing.T) {
+	td := &testDisplay{}
+	m := NewScrollManager(td)
+
+	scroller, _ := NewScroller([]byte("ABCDEFGHIJ"), []byte(">>> "), []byte(" <<<"), 0)
+	scroller.SetSpeed(10)
+
+	m.SetLine(0, scroller)
+	m.SetText(2, []byte("Static"))
+
+	base := time.Unix(1600000000, 0)
+	tick := func(ms int) {
+		t.Helper()
+		td.writes = nil
+		td.updates = 0
+		if err := m.Tick(base.Add(time.Duration(ms) * time.Millisecond)); err != nil {
+			t.Fatal("Unexpected error", err)
+		}
+	}
+
+	// The first tick writes every bound line
+	tick(0)
+	if len(td.writes) != 2 || td.updates != 1 {
+		t.Errorf("Expected 2 writes and 1 update, got %v and %d", td.writes, td.updates)
+	}
+	if !bytes.Equal(td.lines[0], []byte(">>> ABCDEFGH <<<")) || !bytes.Equal(td.lines[2], []byte("Static")) {
+		t.Errorf("Unexpected lines %q", td.lines)
+	}
+
+	// Nothing has changed, so nothing is written
+	tick(50)
+	if len(td.writes) != 0 || td.updates != 0 {
+		t.Errorf("Expected no writes, got %v and %d updates", td.writes, td.updates)
+	}
+
+	// Only the scrolling line is written
+	tick(100)
+	if len(td.writes) != 1 || td.writes[0] != 0 || td.updates != 1 {
+		t.Errorf("Expected a write to line 0, got %v and %d updates", td.writes, td.updates)
+	}
+	if !bytes.Equal(td.lines[0], []byte(">>> BCDEFGHI <<<")) {
+		t.Errorf("Unexpected line %q", td.lines[0])
+	}
+
+	// Swapping the text keeps the scroll cycle running
+	m.SetText(0, []byte("0123456789"))
+	tick(200)
+	if !bytes.Equal(td.lines[0], []byte(">>> 23456789 <<<")) {
+		t.Errorf("Unexpected line %q", td.lines[0])
+	}
+
+	// Unbinding a line leaves it alone
+	m.SetLine(2, nil)
+	m.SetText(1, []byte("New"))
+	tick(250)
+	if len(td.writes) != 1 || td.writes[0] != 1 {
+		t.Errorf("Expected a write to line 1, got %v", td.writes)
+	}
+
+	if err := m.SetLine(3, nil); err == nil {
+		t.Error("Expected error for out of range line")
+	}
+	if err := m.SetText(-1, nil); err == nil {
+		t.Error("Expected error for out of range line")
+	}
+}
+
+// errDisplay is a Display that fails every update
+type errDisplay struct {
+	testDisplay
+}
+
+func (ed *errDisplay) Update() error {
+	return errors.New("disconnected")
+}
+
+func TestScrollManagerRun(t *testing.T) {
+	m := NewScrollManager(&errDisplay{})
+	m.SetText(0, []byte("Hello"))
+
+	stop := make(chan struct{})
+	defer close(stop)
+	if err := m.Run(time.Millisecond, stop); err == nil || err.Error() != "disconnected" {
+		t.Errorf("Expected display error, got %v", err)
+	}
+
+	td := &testDisplay{}
+	m = NewScrollManager(td)
+	m.SetText(1, []byte("Hello"))
+
+	done := make(chan error)
+	stop2 := make(chan struct{})
+	go func() { done <- m.Run(time.Millisecond, stop2) }()
+	time.Sleep(10 * time.Millisecond)
+	close(stop2)
+
+	if err := <-done; err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if !bytes.Equal(td.lines[1], []byte("Hello")) || td.updates != 1 {
+		t.Errorf("Unexpected line %q, %d updates", td.lines[1], td.updates)
+	}
+}
+
+func TestLineFields(t *testing.T) {
+	scroller, _ := NewScrollerString("Hello World", "", "", 0, &ScrollOptions{Width: 8})
+	scroller.SetSpeed(1)
+	line := LineFields{StaticLine("GREETING"), scroller, StaticLine("overflow")}
+
+	base := time.Unix(1600000000, 0)
+	if got := line.Advance(base); string(got) != "GREETINGHello Wo" {
+		t.Errorf("Unexpected line %q", got)
+	}
+	if got := line.Advance(base.Add(time.Second)); string(got) != "GREETINGello Wor" {
+		t.Errorf("Unexpected line %q", got)
+	}
+}
@@ -0,0 +1,235 @@
+package util
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// TerminalMode controls how the terminal handles text that extends past the
+// end of a line
+type TerminalMode uint
+
+const (
+	// TerminalTruncate discards any text past the end of the line, until the
+	// next newline or carriage return
+	TerminalTruncate TerminalMode = iota
+
+	// TerminalWrap continues the text on the next line, scrolling the
+	// display if necessary
+	TerminalWrap
+)
+
+// Terminal is an io.Writer that treats the MFD as a tiny 3 line terminal.
+// A newline moves to the next line, scrolling the display up once the cursor
+// is on the last line, and a carriage return rewrites the current line. The
+// ANSI sequences to clear the screen (ESC [ 2 J), clear the line (ESC [ K),
+// move the cursor (ESC [ H) and reset the terminal (ESC c) are recognized,
+// and any other escape sequences are ignored. The display is updated at the
+// end of every write.
+type Terminal struct {
+	screen  *Screen
+	mode    TerminalMode
+	partial []byte
+	escape  []byte
+	inEsc   bool
+	rewrite bool
+}
+
+// NewTerminal returns a Terminal that writes to the given display
+func NewTerminal(display Display, mode TerminalMode) *Terminal {
+	return &Terminal{
+		screen: NewScreen(display),
+		mode:   mode,
+	}
+}
+
+// SetReplacement controls how runes that are not in the MFD code page are
+// handled. If replace is true, they are replaced with the byte c, otherwise
+// they are dropped.
+func (term *Terminal) SetReplacement(replace bool, c byte) {
+	term.screen.SetReplacement(replace, c)
+}
+
+// Write writes the UTF-8 encoded text to the terminal and updates the
+// display. Multi-byte sequences may be split across writes.
+func (term *Terminal) Write(p []byte) (int, error) {
+	data := append(term.partial, p...)
+	term.partial = nil
+
+	for len(data) > 0 {
+		if !utf8.FullRune(data) {
+			// Save the incomplete sequence for the next write
+			term.partial = append([]byte(nil), data...)
+			break
+		}
+
+		r, size := utf8.DecodeRune(data)
+		data = data[size:]
+		term.writeRune(r)
+	}
+
+	if err := term.screen.Update(); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Line returns the contents of the given line in the MFD code page
+func (term *Terminal) Line(line int) []byte {
+	return term.screen.Line(line)
+}
+
+func (term *Terminal) writeRune(r rune) {
+	sc := term.screen
+
+	if term.inEsc {
+		term.handleEscape(r)
+		return
+	}
+
+	switch r {
+	case '\x1b':
+		term.inEsc = true
+		term.escape = term.escape[:0]
+
+	case '\n':
+		term.rewrite = false
+		term.newline()
+
+	case '\r':
+		// Clear the line only when the new text arrives, so that a CR LF
+		// pair does not erase the line
+		term.rewrite = true
+		sc.col = 0
+
+	case '\b':
+		if sc.col > 0 {
+			sc.col--
+		}
+
+	case '\t':
+		term.writeRune(' ')
+
+	default:
+		if r < 0x20 || r == 0x7f {
+			// Ignore any other control characters
+			return
+		}
+
+		if term.rewrite {
+			term.rewrite = false
+			term.clearLine()
+		}
+
+		data := ConvertStringToX52Charmap(string(r), sc.replace, sc.repl)
+		for _, b := range data {
+			if sc.col >= mfdLineSize {
+				if term.mode != TerminalWrap {
+					return
+				}
+				term.newline()
+			}
+
+			sc.lines[sc.line][sc.col] = b
+			sc.col++
+		}
+	}
+}
+
+// newline moves the cursor to the start of the next line, scrolling the
+// display if the cursor is on the last line
+func (term *Terminal) newline() {
+	sc := term.screen
+	sc.col = 0
+
+	if sc.line < mfdLines-1 {
+		sc.line++
+		return
+	}
+
+	copy(sc.lines[:], sc.lines[1:])
+	term.clearLine()
+}
+
+// clearLine blanks the line from the cursor to the end
+func (term *Terminal) clearLine() {
+	sc := term.screen
+	if sc.col < mfdLineSize {
+		copy(sc.lines[sc.line][sc.col:], bytes.Repeat([]byte{0x20}, mfdLineSize-sc.col))
+	}
+}
+
+// handleEscape processes a rune within an escape sequence
+func (term *Terminal) handleEscape(r rune) {
+	sc := term.screen
+
+	if len(term.escape) == 0 {
+		switch r {
+		case '[':
+			// Control sequence, wait for the final byte
+			term.escape = append(term.escape, '[')
+
+		case 'c':
+			// Reset to initial state
+			sc.Clear()
+			term.inEsc = false
+
+		default:
+			term.inEsc = false
+		}
+		return
+	}
+
+	if r < 0x40 || r > 0x7e {
+		// Parameter or intermediate byte
+		term.escape = append(term.escape, byte(r))
+		return
+	}
+
+	term.inEsc = false
+	params := strings.Split(string(term.escape[1:]), ";")
+	param := func(i, def int) int {
+		if i >= len(params) {
+			return def
+		}
+		v, err := strconv.Atoi(params[i])
+		if err != nil {
+			return def
+		}
+		return v
+	}
+
+	switch r {
+	case 'J':
+		if n := param(0, 0); n == 2 || n == 3 {
+			line, col := sc.Cursor()
+			sc.Clear()
+			sc.line, sc.col = line, col
+		}
+
+	case 'K':
+		if param(0, 0) == 0 {
+			term.clearLine()
+		}
+
+	case 'H', 'f':
+		// Positions are 1 based, clamp them to the screen
+		line := param(0, 1) - 1
+		col := param(1, 1) - 1
+		if line < 0 {
+			line = 0
+		} else if line >= mfdLines {
+			line = mfdLines - 1
+		}
+		if col < 0 {
+			col = 0
+		} else if col >= mfdLineSize {
+			col = mfdLineSize - 1
+		}
+		sc.line, sc.col = line, col
+		term.rewrite = false
+	}
+}
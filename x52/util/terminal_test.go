@@ -0,0 +1,116 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func testTerminal(term *Terminal, expected []string, t *testing.T) {
+	t.Helper()
+	for i, exp := range expected {
+		got := term.Line(i)
+		if !bytes.Equal(got, []byte(exp)) {
+			t.Errorf("Line %d mismatch:\n\texp: %q\n\tgot: %q", i, exp, got)
+		}
+	}
+}
+
+func TestTerminalScroll(t *testing.T) {
+	td := &testDisplay{}
+	term := NewTerminal(td, TerminalTruncate)
+
+	fmt.Fprintln(term, "line 1")
+	fmt.Fprintln(term, "line 2")
+	testTerminal(term, []string{
+		"line 1          ",
+		"line 2          ",
+		"                ",
+	}, t)
+
+	fmt.Fprintln(term, "line 3")
+	fmt.Fprint(term, "line 4")
+	testTerminal(term, []string{
+		"line 2          ",
+		"line 3          ",
+		"line 4          ",
+	}, t)
+
+	if td.updates != 4 {
+		t.Errorf("Expected 4 updates, got %d", td.updates)
+	}
+}
+
+func TestTerminalLongLines(t *testing.T) {
+	term := NewTerminal(&testDisplay{}, TerminalTruncate)
+	fmt.Fprint(term, "0123456789abcdefghij\nnext")
+	testTerminal(term, []string{
+		"0123456789abcdef",
+		"next            ",
+		"                ",
+	}, t)
+
+	term = NewTerminal(&testDisplay{}, TerminalWrap)
+	fmt.Fprint(term, "0123456789abcdefghij\nnext")
+	testTerminal(term, []string{
+		"0123456789abcdef",
+		"ghij            ",
+		"next            ",
+	}, t)
+}
+
+func TestTerminalCarriageReturn(t *testing.T) {
+	term := NewTerminal(&testDisplay{}, TerminalTruncate)
+
+	fmt.Fprint(term, "Progress 100%\rDone")
+	fmt.Fprint(term, "\r\nNext\r")
+	testTerminal(term, []string{
+		"Done            ",
+		"Next            ",
+		"                ",
+	}, t)
+}
+
+func TestTerminalEscapes(t *testing.T) {
+	term := NewTerminal(&testDisplay{}, TerminalTruncate)
+
+	fmt.Fprint(term, "abc\ndef\nghi")
+	fmt.Fprint(term, "\x1b[2J\x1b[Hxy")
+	testTerminal(term, []string{
+		"xy              ",
+		"                ",
+		"                ",
+	}, t)
+
+	fmt.Fprint(term, "\x1b[2;3Hpq\x1b[1mrs\x1b[3;1Hfoo\x1b[1;2H\x1b[K")
+	testTerminal(term, []string{
+		"x               ",
+		"  pqrs          ",
+		"foo             ",
+	}, t)
+
+	fmt.Fprint(term, "\x1bc")
+	fmt.Fprint(term, "reset")
+	testTerminal(term, []string{
+		"reset           ",
+		"                ",
+		"                ",
+	}, t)
+}
+
+func TestTerminalSplitRunes(t *testing.T) {
+	term := NewTerminal(&testDisplay{}, TerminalTruncate)
+
+	data := []byte("Zürich")
+	for i := range data {
+		n, err := term.Write(data[i : i+1])
+		if n != 1 || err != nil {
+			t.Errorf("Unexpected write result %v, %v", n, err)
+		}
+	}
+
+	exp := append([]byte{'Z', 0x81}, []byte("rich          ")...)
+	if got := term.Line(0); !bytes.Equal(got, exp) {
+		t.Errorf("Line mismatch, expected %q, got %q", exp, got)
+	}
+}
@@ -0,0 +1,212 @@
+package util
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// This file handles the transliteration of code points that are not in the
+// MFD character map into close matches that are. It is only used when the
+// ConvertTransliterate flag is passed to the conversion functions.
+
+// transliterate returns a replacement for a rune that is not in the character
+// map, or false if there is no known replacement. The replacement may still
+// contain runes that are not in the character map.
+func transliterate(r rune) (string, bool) {
+	if s, ok := translitmap[r]; ok {
+		return s, true
+	}
+
+	// Decompose the rune and strip any diacritics
+	decomposed := norm.NFD.String(string(r))
+	stripped := strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return r
+	}, decomposed)
+
+	if stripped == "" || stripped == string(r) {
+		return "", false
+	}
+
+	return stripped, true
+}
+
+// Transliteration table for code points that are not handled by stripping
+// diacritics. Greek and Cyrillic letters are transliterated to Latin, except
+// for those Greek letters which have glyphs in the character map.
+var translitmap = map[rune]string{
+	// Typographic punctuation
+	0x2010: "-",   // HYPHEN
+	0x2011: "-",   // NON-BREAKING HYPHEN
+	0x2012: "-",   // FIGURE DASH
+	0x2013: "-",   // EN DASH
+	0x2014: "-",   // EM DASH
+	0x2015: "-",   // HORIZONTAL BAR
+	0x2212: "-",   // MINUS SIGN
+	0x2018: "'",   // LEFT SINGLE QUOTATION MARK
+	0x2019: "'",   // RIGHT SINGLE QUOTATION MARK
+	0x201A: "'",   // SINGLE LOW-9 QUOTATION MARK
+	0x201B: "'",   // SINGLE HIGH-REVERSED-9 QUOTATION MARK
+	0x2032: "'",   // PRIME
+	0x201C: "\"",  // LEFT DOUBLE QUOTATION MARK
+	0x201D: "\"",  // RIGHT DOUBLE QUOTATION MARK
+	0x201E: "\"",  // DOUBLE LOW-9 QUOTATION MARK
+	0x201F: "\"",  // DOUBLE HIGH-REVERSED-9 QUOTATION MARK
+	0x2033: "\"",  // DOUBLE PRIME
+	0x2039: "<",   // SINGLE LEFT-POINTING ANGLE QUOTATION MARK
+	0x203A: ">",   // SINGLE RIGHT-POINTING ANGLE QUOTATION MARK
+	0x00AB: "≪",   // LEFT-POINTING DOUBLE ANGLE QUOTATION MARK
+	0x00BB: "≫",   // RIGHT-POINTING DOUBLE ANGLE QUOTATION MARK
+	0x2026: "...", // HORIZONTAL ELLIPSIS
+	0x2022: "·",   // BULLET
+	0x2027: "·",   // HYPHENATION POINT
+	0x2044: "/",   // FRACTION SLASH
+	0x2215: "/",   // DIVISION SLASH
+	0x2002: " ",   // EN SPACE
+	0x2003: " ",   // EM SPACE
+	0x2009: " ",   // THIN SPACE
+	0x200A: " ",   // HAIR SPACE
+	0x202F: " ",   // NARROW NO-BREAK SPACE
+	0x3000: " ",   // IDEOGRAPHIC SPACE
+
+	// Latin letters without a decomposition
+	0x00D0: "D",  // LATIN CAPITAL LETTER ETH
+	0x00DE: "Th", // LATIN CAPITAL LETTER THORN
+	0x00DF: "ss", // LATIN SMALL LETTER SHARP S
+	0x00F0: "d",  // LATIN SMALL LETTER ETH
+	0x00FE: "th", // LATIN SMALL LETTER THORN
+	0x0110: "D",  // LATIN CAPITAL LETTER D WITH STROKE
+	0x0111: "d",  // LATIN SMALL LETTER D WITH STROKE
+	0x0126: "H",  // LATIN CAPITAL LETTER H WITH STROKE
+	0x0127: "h",  // LATIN SMALL LETTER H WITH STROKE
+	0x0131: "i",  // LATIN SMALL LETTER DOTLESS I
+	0x0141: "L",  // LATIN CAPITAL LETTER L WITH STROKE
+	0x0142: "l",  // LATIN SMALL LETTER L WITH STROKE
+	0x0152: "OE", // LATIN CAPITAL LIGATURE OE
+	0x0153: "oe", // LATIN SMALL LIGATURE OE
+	0x0166: "T",  // LATIN CAPITAL LETTER T WITH STROKE
+	0x0167: "t",  // LATIN SMALL LETTER T WITH STROKE
+	0x1E9E: "SS", // LATIN CAPITAL LETTER SHARP S
+
+	// Greek
+	0x0391: "A",  // GREEK CAPITAL LETTER ALPHA
+	0x0392: "V",  // GREEK CAPITAL LETTER BETA
+	0x0395: "E",  // GREEK CAPITAL LETTER EPSILON
+	0x0396: "Z",  // GREEK CAPITAL LETTER ZETA
+	0x0397: "I",  // GREEK CAPITAL LETTER ETA
+	0x0399: "I",  // GREEK CAPITAL LETTER IOTA
+	0x039A: "K",  // GREEK CAPITAL LETTER KAPPA
+	0x039C: "M",  // GREEK CAPITAL LETTER MU
+	0x039D: "N",  // GREEK CAPITAL LETTER NU
+	0x039F: "O",  // GREEK CAPITAL LETTER OMICRON
+	0x03A1: "R",  // GREEK CAPITAL LETTER RHO
+	0x03A4: "T",  // GREEK CAPITAL LETTER TAU
+	0x03A5: "Y",  // GREEK CAPITAL LETTER UPSILON
+	0x03A7: "Ch", // GREEK CAPITAL LETTER CHI
+	0x03B2: "v",  // GREEK SMALL LETTER BETA
+	0x03B3: "g",  // GREEK SMALL LETTER GAMMA
+	0x03B4: "d",  // GREEK SMALL LETTER DELTA
+	0x03B5: "e",  // GREEK SMALL LETTER EPSILON
+	0x03B6: "z",  // GREEK SMALL LETTER ZETA
+	0x03B7: "i",  // GREEK SMALL LETTER ETA
+	0x03B8: "th", // GREEK SMALL LETTER THETA
+	0x03B9: "i",  // GREEK SMALL LETTER IOTA
+	0x03BA: "k",  // GREEK SMALL LETTER KAPPA
+	0x03BB: "l",  // GREEK SMALL LETTER LAMDA
+	0x03BC: "m",  // GREEK SMALL LETTER MU
+	0x03BD: "n",  // GREEK SMALL LETTER NU
+	0x03BE: "x",  // GREEK SMALL LETTER XI
+	0x03BF: "o",  // GREEK SMALL LETTER OMICRON
+	0x03C0: "p",  // GREEK SMALL LETTER PI
+	0x03C1: "r",  // GREEK SMALL LETTER RHO
+	0x03C2: "s",  // GREEK SMALL LETTER FINAL SIGMA
+	0x03C3: "s",  // GREEK SMALL LETTER SIGMA
+	0x03C4: "t",  // GREEK SMALL LETTER TAU
+	0x03C5: "y",  // GREEK SMALL LETTER UPSILON
+	0x03C6: "f",  // GREEK SMALL LETTER PHI
+	0x03C7: "ch", // GREEK SMALL LETTER CHI
+	0x03C8: "ps", // GREEK SMALL LETTER PSI
+	0x03C9: "o",  // GREEK SMALL LETTER OMEGA
+
+	// Cyrillic
+	0x0410: "A",    // CYRILLIC CAPITAL LETTER A
+	0x0411: "B",    // CYRILLIC CAPITAL LETTER BE
+	0x0412: "V",    // CYRILLIC CAPITAL LETTER VE
+	0x0413: "G",    // CYRILLIC CAPITAL LETTER GHE
+	0x0414: "D",    // CYRILLIC CAPITAL LETTER DE
+	0x0415: "E",    // CYRILLIC CAPITAL LETTER IE
+	0x0416: "Zh",   // CYRILLIC CAPITAL LETTER ZHE
+	0x0417: "Z",    // CYRILLIC CAPITAL LETTER ZE
+	0x0418: "I",    // CYRILLIC CAPITAL LETTER I
+	0x0419: "Y",    // CYRILLIC CAPITAL LETTER SHORT I
+	0x041A: "K",    // CYRILLIC CAPITAL LETTER KA
+	0x041B: "L",    // CYRILLIC CAPITAL LETTER EL
+	0x041C: "M",    // CYRILLIC CAPITAL LETTER EM
+	0x041D: "N",    // CYRILLIC CAPITAL LETTER EN
+	0x041E: "O",    // CYRILLIC CAPITAL LETTER O
+	0x041F: "P",    // CYRILLIC CAPITAL LETTER PE
+	0x0420: "R",    // CYRILLIC CAPITAL LETTER ER
+	0x0421: "S",    // CYRILLIC CAPITAL LETTER ES
+	0x0422: "T",    // CYRILLIC CAPITAL LETTER TE
+	0x0423: "U",    // CYRILLIC CAPITAL LETTER U
+	0x0424: "F",    // CYRILLIC CAPITAL LETTER EF
+	0x0425: "Kh",   // CYRILLIC CAPITAL LETTER HA
+	0x0426: "Ts",   // CYRILLIC CAPITAL LETTER TSE
+	0x0427: "Ch",   // CYRILLIC CAPITAL LETTER CHE
+	0x0428: "Sh",   // CYRILLIC CAPITAL LETTER SHA
+	0x0429: "Shch", // CYRILLIC CAPITAL LETTER SHCHA
+	0x042A: "",     // CYRILLIC CAPITAL LETTER HARD SIGN
+	0x042B: "Y",    // CYRILLIC CAPITAL LETTER YERU
+	0x042C: "",     // CYRILLIC CAPITAL LETTER SOFT SIGN
+	0x042D: "E",    // CYRILLIC CAPITAL LETTER E
+	0x042E: "Yu",   // CYRILLIC CAPITAL LETTER YU
+	0x042F: "Ya",   // CYRILLIC CAPITAL LETTER YA
+	0x0430: "a",    // CYRILLIC SMALL LETTER A
+	0x0431: "b",    // CYRILLIC SMALL LETTER BE
+	0x0432: "v",    // CYRILLIC SMALL LETTER VE
+	0x0433: "g",    // CYRILLIC SMALL LETTER GHE
+	0x0434: "d",    // CYRILLIC SMALL LETTER DE
+	0x0435: "e",    // CYRILLIC SMALL LETTER IE
+	0x0436: "zh",   // CYRILLIC SMALL LETTER ZHE
+	0x0437: "z",    // CYRILLIC SMALL LETTER ZE
+	0x0438: "i",    // CYRILLIC SMALL LETTER I
+	0x0439: "y",    // CYRILLIC SMALL LETTER SHORT I
+	0x043A: "k",    // CYRILLIC SMALL LETTER KA
+	0x043B: "l",    // CYRILLIC SMALL LETTER EL
+	0x043C: "m",    // CYRILLIC SMALL LETTER EM
+	0x043D: "n",    // CYRILLIC SMALL LETTER EN
+	0x043E: "o",    // CYRILLIC SMALL LETTER O
+	0x043F: "p",    // CYRILLIC SMALL LETTER PE
+	0x0440: "r",    // CYRILLIC SMALL LETTER ER
+	0x0441: "s",    // CYRILLIC SMALL LETTER ES
+	0x0442: "t",    // CYRILLIC SMALL LETTER TE
+	0x0443: "u",    // CYRILLIC SMALL LETTER U
+	0x0444: "f",    // CYRILLIC SMALL LETTER EF
+	0x0445: "kh",   // CYRILLIC SMALL LETTER HA
+	0x0446: "ts",   // CYRILLIC SMALL LETTER TSE
+	0x0447: "ch",   // CYRILLIC SMALL LETTER CHE
+	0x0448: "sh",   // CYRILLIC SMALL LETTER SHA
+	0x0449: "shch", // CYRILLIC SMALL LETTER SHCHA
+	0x044A: "",     // CYRILLIC SMALL LETTER HARD SIGN
+	0x044B: "y",    // CYRILLIC SMALL LETTER YERU
+	0x044C: "",     // CYRILLIC SMALL LETTER SOFT SIGN
+	0x044D: "e",    // CYRILLIC SMALL LETTER E
+	0x044E: "yu",   // CYRILLIC SMALL LETTER YU
+	0x044F: "ya",   // CYRILLIC SMALL LETTER YA
+	0x0401: "Yo",   // CYRILLIC CAPITAL LETTER IO
+	0x0451: "yo",   // CYRILLIC SMALL LETTER IO
+	0x0404: "Ye",   // CYRILLIC CAPITAL LETTER UKRAINIAN IE
+	0x0454: "ye",   // CYRILLIC SMALL LETTER UKRAINIAN IE
+	0x0406: "I",    // CYRILLIC CAPITAL LETTER BYELORUSSIAN-UKRAINIAN I
+	0x0456: "i",    // CYRILLIC SMALL LETTER BYELORUSSIAN-UKRAINIAN I
+	0x0407: "Yi",   // CYRILLIC CAPITAL LETTER YI
+	0x0457: "yi",   // CYRILLIC SMALL LETTER YI
+	0x0490: "G",    // CYRILLIC CAPITAL LETTER GHE WITH UPTURN
+	0x0491: "g",    // CYRILLIC SMALL LETTER GHE WITH UPTURN
+	0x040E: "U",    // CYRILLIC CAPITAL LETTER SHORT U
+	0x045E: "u",    // CYRILLIC SMALL LETTER SHORT U
+}
@@ -0,0 +1,53 @@
+package util
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		input string
+		exp   []byte
+	}{
+		// Mapped characters are not transliterated
+		{"Zürich", []byte{'Z', 0x81, 'r', 'i', 'c', 'h'}},
+		{"ΓΔΘ", []byte{0x14, 0x15, 0x16}},
+		// Combining sequences are composed before lookup
+		{"Zu\u0308rich", []byte{'Z', 0x81, 'r', 'i', 'c', 'h'}},
+		// Diacritics are stripped if there is no precomposed glyph
+		{"Kraków", []byte("Krak\xe2w")},
+		{"Dvořák", []byte("Dvor\xe0k")},
+		{"Ångström", []byte{'A', 'n', 'g', 's', 't', 'r', 0x94, 'm'}},
+		{"Łódź", []byte("L\xe2dz")},
+		// Typographic punctuation
+		{"“Hi” – it’s…", []byte("\"Hi\" - it's...")},
+		{"«Bonjour»", []byte{0xFB, 'B', 'o', 'n', 'j', 'o', 'u', 'r', 0xFC}},
+		// Greek and Cyrillic
+		{"Κρήτη", []byte("Kriti")},
+		{"Москва", []byte("Moskva")},
+		{"Щука и ёж", []byte("Shchuka i yozh")},
+		// Unknown runes still fall back to the replacement
+		{"☃", []byte{ReplaceMissing}},
+	}
+
+	for _, tc := range tests {
+		got := ConvertString(tc.input, ConvertReplace|ConvertTransliterate, ReplaceMissing)
+		if !bytes.Equal(got, tc.exp) {
+			t.Errorf("Transliterating %q, expected %q, got %q", tc.input, tc.exp, got)
+		}
+	}
+}
+
+func TestTransliterateDisabled(t *testing.T) {
+	got := ConvertString("“Łódź”", ConvertReplace, ReplaceMissing)
+	exp := []byte{ReplaceMissing, ReplaceMissing, 0xE2, 'd', ReplaceMissing, ReplaceMissing}
+	if !bytes.Equal(got, exp) {
+		t.Errorf("Expected %#x, got %#x", exp, got)
+	}
+
+	got = ConvertString("“Łódź”", ConvertTransliterate, ReplaceMissing)
+	if exp := []byte("\"L\xe2dz\""); !bytes.Equal(got, exp) {
+		t.Errorf("Expected %q, got %q", exp, got)
+	}
+}
@@ -0,0 +1,263 @@
+package util
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// VScroller scrolls a list of lines vertically through the lines of the MFD,
+// such as a checklist or a log. An optional header is fixed on the first line
+// of the MFD, and the remaining lines show a window into the list, which can
+// be moved with Next and Previous, or automatically with Advance. It is safe
+// to move the window while the lines are being advanced by a ScrollManager.
+type VScroller struct {
+	mu       sync.Mutex
+	lines    [][]byte
+	header   []byte
+	flags    VScrollFlags
+	top      int
+	interval time.Duration
+	lastStep time.Time
+	started  bool
+}
+
+// VScrollFlags control the behavior of the vertical scroller
+type VScrollFlags uint
+
+const (
+	// VScrollPage moves the window by a full page of visible lines at a
+	// time, instead of rolling by a single line
+	VScrollPage VScrollFlags = 1 << iota
+
+	// VScrollWrap wraps around from the end of the list to the start, and
+	// from the start to the end, instead of stopping at either end
+	VScrollWrap
+)
+
+// NewVScroller returns a VScroller showing the start of the given lines,
+// which must be in the code page of the MFD display. Lines longer than the
+// width of the MFD are truncated.
+func NewVScroller(lines [][]byte, flags VScrollFlags) *VScroller {
+	return &VScroller{
+		lines: lines,
+		flags: flags,
+	}
+}
+
+// SetHeader fixes the header on the first line of the MFD, leaving the
+// remaining lines for the list. A nil header uses every line for the list.
+func (vs *VScroller) SetHeader(header []byte) {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	vs.header = header
+	vs.setTop(vs.top)
+}
+
+// SetLines replaces the list of lines, keeping the window at the same position
+// where possible
+func (vs *VScroller) SetLines(lines [][]byte) {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	vs.lines = lines
+	vs.setTop(vs.top)
+}
+
+// SetInterval sets the time between steps when using Advance. An interval of
+// 0 disables automatic scrolling.
+func (vs *VScroller) SetInterval(interval time.Duration) {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	vs.interval = interval
+	vs.started = false
+}
+
+// Top returns the index in the list of the first visible line
+func (vs *VScroller) Top() int {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	return vs.top
+}
+
+// SetTop moves the window so that the given index in the list is the first
+// visible line
+func (vs *VScroller) SetTop(top int) {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	vs.setTop(top)
+	vs.started = false
+}
+
+// Next moves the window forward by a line or a page, and restarts the interval
+// used by Advance. It returns false if the window did not move, because it is
+// already at the end of the list.
+func (vs *VScroller) Next() bool {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	vs.started = false
+	return vs.step(1)
+}
+
+// Previous moves the window back by a line or a page, and restarts the
+// interval used by Advance. It returns false if the window did not move,
+// because it is already at the start of the list.
+func (vs *VScroller) Previous() bool {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	vs.started = false
+	return vs.step(-1)
+}
+
+// Lines returns the visible lines for passing to the MFD text API, each padded
+// to the full width of the MFD
+func (vs *VScroller) Lines() [mfdLines][]byte {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	return vs.visibleLines()
+}
+
+// visibleLines returns the visible lines
+func (vs *VScroller) visibleLines() [mfdLines][]byte {
+	var out [mfdLines][]byte
+
+	row := 0
+	if vs.header != nil {
+		out[0] = alignLine(vs.header, AlignLeft)
+		row = 1
+	}
+
+	n := len(vs.lines)
+	for i := 0; row < mfdLines; i, row = i+1, row+1 {
+		idx := vs.top + i
+		if vs.flags&VScrollWrap != 0 && n > vs.visible() && vs.flags&VScrollPage == 0 {
+			idx %= n
+		}
+
+		if idx < n {
+			out[row] = alignLine(vs.lines[idx], AlignLeft)
+		} else {
+			out[row] = alignLine(nil, AlignLeft)
+		}
+	}
+
+	return out
+}
+
+// Line returns a LineSource for a single line of the MFD, which advances the
+// scroller with Advance and returns the corresponding line. This allows the
+// scroller to be bound to the lines of a ScrollManager. It returns an error
+// if the line is not on the MFD.
+func (vs *VScroller) Line(line int) (LineSource, error) {
+	if line < 0 || line >= mfdLines {
+		return nil, errors.New("line number out of range")
+	}
+
+	return vscrollLine{vs, line}, nil
+}
+
+// Advance moves the window by a line or a page for every interval that has
+// passed since the previous step, and returns the visible lines. The first
+// call after creating the scroller, setting the interval or moving the window
+// starts the interval. Without VScrollWrap, the window stops at the end of
+// the list.
+func (vs *VScroller) Advance(now time.Time) [mfdLines][]byte {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	if vs.interval > 0 {
+		if !vs.started {
+			vs.lastStep = now
+			vs.started = true
+		}
+
+		for now.Sub(vs.lastStep) >= vs.interval {
+			vs.lastStep = vs.lastStep.Add(vs.interval)
+			if !vs.step(1) {
+				// Hold at the end of the list
+				vs.lastStep = now
+			}
+		}
+	}
+
+	return vs.visibleLines()
+}
+
+// visible returns the number of MFD lines available for the list
+func (vs *VScroller) visible() int {
+	if vs.header != nil {
+		return mfdLines - 1
+	}
+
+	return mfdLines
+}
+
+// step moves the window by the given number of lines or pages
+func (vs *VScroller) step(dir int) bool {
+	n := len(vs.lines)
+	visible := vs.visible()
+	if n <= visible {
+		return false
+	}
+
+	old := vs.top
+	if vs.flags&VScrollPage != 0 {
+		pages := (n + visible - 1) / visible
+		page := vs.top/visible + dir
+		if vs.flags&VScrollWrap != 0 {
+			page = (page + pages) % pages
+		}
+		vs.setTop(page * visible)
+	} else if vs.flags&VScrollWrap != 0 {
+		vs.top = (vs.top + dir + n) % n
+	} else {
+		vs.setTop(vs.top + dir)
+	}
+
+	return vs.top != old
+}
+
+// setTop moves the window to the given index, limited to the valid range
+func (vs *VScroller) setTop(top int) {
+	n := len(vs.lines)
+	visible := vs.visible()
+
+	var last int
+	switch {
+	case n <= visible:
+		last = 0
+	case vs.flags&VScrollPage != 0:
+		top -= top % visible
+		last = (n - 1) / visible * visible
+	case vs.flags&VScrollWrap != 0:
+		last = n - 1
+	default:
+		last = n - visible
+	}
+
+	if top > last {
+		top = last
+	}
+	if top < 0 {
+		top = 0
+	}
+	vs.top = top
+}
+
+// vscrollLine is a LineSource for a single line of a VScroller
+type vscrollLine struct {
+	vs   *VScroller
+	line int
+}
+
+// Advance advances the scroller and returns the line
+func (vl vscrollLine) Advance(now time.Time) []byte {
+	return vl.vs.Advance(now)[vl.line]
+}
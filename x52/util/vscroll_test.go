@@ -0,0 +1,178 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func vscrollLines(n int) [][]byte {
+	lines := make([][]byte, n)
+	for i := range lines {
+		lines[i] = []byte{'L', 'i', 'n', 'e', ' ', byte('A' + i)}
+	}
+	return lines
+}
+
+// testVScroll checks that the visible lines match the first character after
+// "Line " of each expected string, with space for blank lines
+func testVScroll(vs *VScroller, exp string, t *testing.T) {
+	t.Helper()
+	var got []byte
+	for _, line := range vs.Lines() {
+		if len(line) != mfdLineSize {
+			t.Fatalf("Line is not padded: %q", line)
+		}
+		switch {
+		case line[0] == 'L':
+			got = append(got, line[5])
+		case line[0] == 'H':
+			got = append(got, 'H')
+		default:
+			got = append(got, ' ')
+		}
+	}
+
+	if string(got) != exp {
+		t.Errorf("Expected %q, got %q", exp, got)
+	}
+}
+
+func TestVScrollRoll(t *testing.T) {
+	vs := NewVScroller(vscrollLines(5), 0)
+	testVScroll(vs, "ABC", t)
+
+	for _, exp := range []string{"BCD", "CDE"} {
+		if !vs.Next() {
+			t.Error("Next did not move")
+		}
+		testVScroll(vs, exp, t)
+	}
+
+	if vs.Next() {
+		t.Error("Next moved past the end")
+	}
+	testVScroll(vs, "CDE", t)
+
+	vs.Previous()
+	testVScroll(vs, "BCD", t)
+	vs.SetTop(0)
+	if vs.Previous() {
+		t.Error("Previous moved past the start")
+	}
+}
+
+func TestVScrollRollWrap(t *testing.T) {
+	vs := NewVScroller(vscrollLines(4), VScrollWrap)
+	for _, exp := range []string{"BCD", "CDA", "DAB", "ABC"} {
+		vs.Next()
+		testVScroll(vs, exp, t)
+	}
+
+	vs.Previous()
+	testVScroll(vs, "DAB", t)
+}
+
+func TestVScrollPage(t *testing.T) {
+	vs := NewVScroller(vscrollLines(7), VScrollPage)
+	testVScroll(vs, "ABC", t)
+	vs.Next()
+	testVScroll(vs, "DEF", t)
+	vs.Next()
+	testVScroll(vs, "G  ", t)
+	if vs.Next() {
+		t.Error("Next moved past the last page")
+	}
+
+	vs.SetTop(4)
+	if vs.Top() != 3 {
+		t.Errorf("Expected top to snap to page start, got %d", vs.Top())
+	}
+
+	vs = NewVScroller(vscrollLines(7), VScrollPage|VScrollWrap)
+	vs.Previous()
+	testVScroll(vs, "G  ", t)
+	vs.Next()
+	testVScroll(vs, "ABC", t)
+}
+
+func TestVScrollHeader(t *testing.T) {
+	vs := NewVScroller(vscrollLines(5), VScrollPage)
+	vs.SetHeader([]byte("Header"))
+	testVScroll(vs, "HAB", t)
+	vs.Next()
+	testVScroll(vs, "HCD", t)
+	vs.Next()
+	testVScroll(vs, "HE ", t)
+
+	// Removing the header moves the window to the start of the page
+	vs.SetHeader(nil)
+	testVScroll(vs, "DE ", t)
+}
+
+func TestVScrollShort(t *testing.T) {
+	vs := NewVScroller(vscrollLines(2), VScrollWrap)
+	if vs.Next() || vs.Previous() {
+		t.Error("Short list should not scroll")
+	}
+	testVScroll(vs, "AB ", t)
+
+	vs.SetLines(vscrollLines(6))
+	vs.SetTop(5)
+	testVScroll(vs, "FAB", t)
+	vs.SetLines(vscrollLines(3))
+	testVScroll(vs, "ABC", t)
+}
+
+func TestVScrollAdvance(t *testing.T) {
+	vs := NewVScroller(vscrollLines(5), 0)
+	vs.SetInterval(time.Second)
+
+	base := time.Unix(1600000000, 0)
+	at := func(ms int) time.Time {
+		return base.Add(time.Duration(ms) * time.Millisecond)
+	}
+
+	vs.Advance(at(0))
+	testVScroll(vs, "ABC", t)
+	vs.Advance(at(999))
+	testVScroll(vs, "ABC", t)
+	vs.Advance(at(1000))
+	testVScroll(vs, "BCD", t)
+
+	// Manual control restarts the interval
+	vs.Next()
+	vs.Advance(at(1500))
+	testVScroll(vs, "CDE", t)
+	vs.Previous()
+	vs.Advance(at(1600))
+	vs.Advance(at(2599))
+	testVScroll(vs, "BCD", t)
+	vs.Advance(at(2600))
+	testVScroll(vs, "CDE", t)
+
+	// Holds at the end
+	vs.Advance(at(10000))
+	testVScroll(vs, "CDE", t)
+
+	// Lines can be bound individually to a ScrollManager
+	td := &testDisplay{}
+	m := NewScrollManager(td)
+	vs.SetTop(0)
+	for i := 0; i < mfdLines; i++ {
+		src, err := vs.Line(i)
+		if err != nil {
+			t.Fatalf("Line %v: unexpected error %v", i, err)
+		}
+		m.SetLine(i, src)
+	}
+	for _, line := range []int{-1, mfdLines} {
+		if _, err := vs.Line(line); err == nil {
+			t.Errorf("Line %v: expected error", line)
+		}
+	}
+	m.Tick(at(20000))
+	m.Tick(at(21000))
+	if string(td.lines[0][:6]) != "Line B" || string(td.lines[2][:6]) != "Line D" {
+		t.Errorf("Unexpected lines %q", td.lines)
+	}
+}
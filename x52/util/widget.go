@@ -0,0 +1,208 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Widget is a rectangular element of the MFD, such as a gauge or a numeric
+// field, which can be placed in a Layout
+type Widget interface {
+	// Size returns the width and height of the widget in characters
+	Size() (width, height int)
+
+	// Draw returns the contents of the widget in the MFD code page, with
+	// one entry per line, each of which is exactly the width of the widget
+	Draw() [][]byte
+}
+
+// Layout places widgets at fixed positions on the MFD, and writes them to a
+// display. Widgets are drawn in the order they were added, so a widget that
+// overlaps another one is drawn on top of it.
+type Layout struct {
+	screen  *Screen
+	widgets []layoutWidget
+}
+
+// layoutWidget is a widget and its position in a Layout
+type layoutWidget struct {
+	line, col int
+	widget    Widget
+}
+
+// NewLayout returns an empty Layout that writes to the given display
+func NewLayout(display Display) *Layout {
+	return &Layout{screen: NewScreen(display)}
+}
+
+// Add places the widget with its top left corner at the given line and
+// column, both of which start from 0. It returns an error if the widget does
+// not fit on the MFD at that position.
+func (l *Layout) Add(line, col int, w Widget) error {
+	width, height := w.Size()
+	if line < 0 || col < 0 || line+height > mfdLines || col+width > mfdLineSize {
+		return errors.New("widget does not fit on the MFD")
+	}
+
+	l.widgets = append(l.widgets, layoutWidget{line, col, w})
+	return nil
+}
+
+// Lines draws every widget, and returns the lines of the MFD, each padded to
+// the full width of the MFD. Parts of the MFD that are not covered by any
+// widget are blank.
+func (l *Layout) Lines() [mfdLines][]byte {
+	l.draw()
+
+	var out [mfdLines][]byte
+	for i := range out {
+		out[i] = l.screen.Line(i)
+	}
+	return out
+}
+
+// Line returns a LineSource for a single line of the MFD, which draws every
+// widget and returns the corresponding line. This allows the layout to be
+// bound to the lines of a ScrollManager or a page of a Pager. It returns an
+// error if the line is not on the MFD.
+func (l *Layout) Line(line int) (LineSource, error) {
+	if line < 0 || line >= mfdLines {
+		return nil, errors.New("line number out of range")
+	}
+
+	return layoutLine{l, line}, nil
+}
+
+// Update draws every widget, writes the lines that have changed since the
+// previous update to the display, and then updates the display
+func (l *Layout) Update() error {
+	l.draw()
+	return l.screen.Update()
+}
+
+// draw redraws every widget on the screen
+func (l *Layout) draw() {
+	l.screen.Clear()
+	for _, lw := range l.widgets {
+		for i, data := range lw.widget.Draw() {
+			l.screen.put(lw.line+i, lw.col, data)
+		}
+	}
+}
+
+// layoutLine is a LineSource for a single line of a Layout
+type layoutLine struct {
+	l    *Layout
+	line int
+}
+
+// Advance returns the line of the layout
+func (ll layoutLine) Advance(now time.Time) []byte {
+	return ll.l.Lines()[ll.line]
+}
+
+// Annunciator is a Widget that shows a fixed label when it is on, and blank
+// space when it is off, such as a warning light
+type Annunciator struct {
+	label []byte
+	on    bool
+}
+
+// NewAnnunciator returns an Annunciator with the given label, which is
+// initially off
+func NewAnnunciator(label string) *Annunciator {
+	return &Annunciator{label: truncate(layoutConvert(label), mfdLineSize, 0)}
+}
+
+// SetOn turns the annunciator on or off
+func (a *Annunciator) SetOn(on bool) {
+	a.on = on
+}
+
+// On returns true if the annunciator is on
+func (a *Annunciator) On() bool {
+	return a.on
+}
+
+// Size returns the size of the annunciator, which is the length of its label
+func (a *Annunciator) Size() (width, height int) {
+	return len(a.label), 1
+}
+
+// Draw returns the label if the annunciator is on, or blank space otherwise
+func (a *Annunciator) Draw() [][]byte {
+	if !a.on {
+		return [][]byte{bytes.Repeat([]byte{0x20}, len(a.label))}
+	}
+
+	return [][]byte{append([]byte(nil), a.label...)}
+}
+
+// NumberField is a Widget that shows a number with a fixed number of decimal
+// places, right aligned in a field of fixed width and followed by an optional
+// unit. A number that does not fit in the field is shown as a row of
+// asterisks, so that a truncated value is never mistaken for a real one.
+type NumberField struct {
+	width     int
+	precision int
+	unit      []byte
+	value     float64
+	valid     bool
+}
+
+// NewNumberField returns a NumberField with the given total width, including
+// the unit, and number of decimal places. The field is blank until a value is
+// set.
+func NewNumberField(width, precision int, unit string) (*NumberField, error) {
+	unitData := layoutConvert(unit)
+	if width < 1 || width > mfdLineSize {
+		return nil, errors.New("width out of range")
+	}
+	if len(unitData) >= width {
+		return nil, errors.New("unit does not fit in the field")
+	}
+	if precision < 0 {
+		return nil, errors.New("precision out of range")
+	}
+
+	return &NumberField{
+		width:     width,
+		precision: precision,
+		unit:      unitData,
+	}, nil
+}
+
+// SetValue sets the number shown in the field
+func (nf *NumberField) SetValue(value float64) {
+	nf.value = value
+	nf.valid = true
+}
+
+// Clear blanks the number in the field, leaving the unit
+func (nf *NumberField) Clear() {
+	nf.valid = false
+}
+
+// Size returns the size of the field
+func (nf *NumberField) Size() (width, height int) {
+	return nf.width, 1
+}
+
+// Draw returns the formatted number followed by the unit
+func (nf *NumberField) Draw() [][]byte {
+	avail := nf.width - len(nf.unit)
+	line := bytes.Repeat([]byte{0x20}, avail)
+
+	if nf.valid {
+		num := strconv.FormatFloat(nf.value, 'f', nf.precision, 64)
+		if len(num) > avail {
+			line = bytes.Repeat([]byte{'*'}, avail)
+		} else {
+			copy(line[avail-len(num):], num)
+		}
+	}
+
+	return [][]byte{append(line, nf.unit...)}
+}
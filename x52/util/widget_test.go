@@ -0,0 +1,155 @@
+package util
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestLayout(t *testing.T) {
+	td := &testDisplay{}
+	layout := NewLayout(td)
+
+	warn := NewAnnunciator("WARN")
+	alt, _ := NewNumberField(8, 0, "ft")
+	spd, _ := NewNumberField(7, 1, "kt")
+
+	for _, add := range []struct {
+		line, col int
+		w         Widget
+	}{
+		{0, 0, warn},
+		{0, 8, alt},
+		{1, 9, spd},
+	} {
+		if err := layout.Add(add.line, add.col, add.w); err != nil {
+			t.Fatal("Unexpected error", err)
+		}
+	}
+
+	warn.SetOn(true)
+	alt.SetValue(12500)
+	spd.SetValue(250.04)
+
+	if err := layout.Update(); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	expected := []string{
+		"WARN     12500ft",
+		"         250.0kt",
+		"                ",
+	}
+	for i, exp := range expected {
+		if !bytes.Equal(td.lines[i], []byte(exp)) {
+			t.Errorf("Line %d mismatch, expected %q, got %q", i, exp, td.lines[i])
+		}
+	}
+
+	// Only the changed line is written on the next update
+	td.writes = nil
+	warn.SetOn(false)
+	if err := layout.Update(); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	if len(td.writes) != 1 || td.writes[0] != 0 {
+		t.Errorf("Unexpected writes %v", td.writes)
+	}
+	if got := layout.Lines()[0]; string(got) != "         12500ft" {
+		t.Errorf("Unexpected line %q", got)
+	}
+	src, err := layout.Line(1)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	if got := src.Advance(time.Time{}); string(got) != "         250.0kt" {
+		t.Errorf("Unexpected line %q", got)
+	}
+	if _, err := layout.Line(mfdLines); err == nil {
+		t.Error("Expected error for line out of range")
+	}
+}
+
+func TestLayoutBounds(t *testing.T) {
+	layout := NewLayout(&testDisplay{})
+	bar, _ := NewBar(10, 0, 1)
+	spark, _ := NewSparkline(4, 0, 1)
+
+	for _, tc := range []struct {
+		line, col int
+		w         Widget
+	}{
+		{0, 7, bar},
+		{3, 0, bar},
+		{-1, 0, bar},
+		{0, -1, bar},
+		{1, 0, spark},
+	} {
+		if err := layout.Add(tc.line, tc.col, tc.w); err == nil {
+			t.Errorf("Expected error adding widget at (%d, %d)", tc.line, tc.col)
+		}
+	}
+
+	if err := layout.Add(0, 6, bar); err != nil {
+		t.Error("Unexpected error", err)
+	}
+	if err := layout.Add(0, 0, spark); err != nil {
+		t.Error("Unexpected error", err)
+	}
+}
+
+func TestAnnunciator(t *testing.T) {
+	a := NewAnnunciator("GEAR")
+	if w, h := a.Size(); w != 4 || h != 1 {
+		t.Errorf("Unexpected size (%d, %d)", w, h)
+	}
+
+	if got := a.Draw()[0]; string(got) != "    " {
+		t.Errorf("Expected blank annunciator, got %q", got)
+	}
+
+	a.SetOn(true)
+	if !a.On() {
+		t.Error("Expected annunciator to be on")
+	}
+	if got := a.Draw()[0]; string(got) != "GEAR" {
+		t.Errorf("Expected label, got %q", got)
+	}
+}
+
+func TestNumberField(t *testing.T) {
+	nf, err := NewNumberField(6, 1, "%")
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	for _, tc := range []struct {
+		value    float64
+		expected string
+	}{
+		{0, "  0.0%"},
+		{99.95, "100.0%"},
+		{-12.34, "-12.3%"},
+		{1000, "*****%"},
+	} {
+		nf.SetValue(tc.value)
+		if got := nf.Draw()[0]; string(got) != tc.expected {
+			t.Errorf("Value %v: expected %q, got %q", tc.value, tc.expected, got)
+		}
+	}
+
+	nf.Clear()
+	if got := nf.Draw()[0]; string(got) != "     %" {
+		t.Errorf("Expected cleared field, got %q", got)
+	}
+
+	if _, err := NewNumberField(2, 0, "kt"); err == nil {
+		t.Error("Expected error for unit wider than the field")
+	}
+	if _, err := NewNumberField(17, 0, ""); err == nil {
+		t.Error("Expected error for field wider than the MFD")
+	}
+	if _, err := NewNumberField(4, -1, ""); err == nil {
+		t.Error("Expected error for negative precision")
+	}
+}
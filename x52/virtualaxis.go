@@ -0,0 +1,149 @@
+package x52
+
+// AxisInput is a physical axis that contributes to a VirtualAxis. The values
+// of the axis from Min to Max inclusive are scaled to the range of the virtual
+// axis, and values outside that range are clamped. A range that covers part of
+// the travel of the physical axis allows the axis to be split, such as the
+// throttle into two engine axes.
+type AxisInput struct {
+	Min, Max int
+
+	// Invert reverses the direction of the axis
+	Invert bool
+}
+
+// VirtualAxis builds an axis from one or more physical axes, such as a rudder
+// from the twist of the stick and a rotary on the throttle. The library does
+// not read the joystick input, so the application passes the values of the
+// physical axes to Update, which returns the value of the virtual axis. The
+// value can be passed on to AxisZones, such as to light an LED in a range of
+// the virtual axis.
+//
+// The virtual axis can be trimmed by holding a button while moving the axis.
+// When the button is released, the offset of the axis from its center becomes
+// the trim, and the value is held until the axis returns to its center, after
+// which the axis moves from the trimmed value.
+type VirtualAxis struct {
+	min, max int
+	inputs   []AxisInput
+
+	trim      int
+	trimHeld  bool
+	value     int
+	recenter  bool
+	releaseAt int
+}
+
+// NewVirtualAxis returns a virtual axis with values from min to max inclusive,
+// built from the given physical axes. Each physical axis is scaled to the full
+// range of the virtual axis, and the offsets of the physical axes from their
+// centers are added together.
+func NewVirtualAxis(min, max int, inputs ...AxisInput) (*VirtualAxis, error) {
+	if min >= max {
+		return nil, errInvalidParam("axis minimum is not less than maximum")
+	}
+	if len(inputs) == 0 {
+		return nil, errInvalidParam("virtual axis has no inputs")
+	}
+	for _, in := range inputs {
+		if in.Min >= in.Max {
+			return nil, errInvalidParam("axis minimum is not less than maximum")
+		}
+	}
+
+	return &VirtualAxis{
+		min:    min,
+		max:    max,
+		inputs: append([]AxisInput(nil), inputs...),
+		value:  (min + max) / 2,
+	}, nil
+}
+
+// Update takes the values of the physical axes, in the order they were passed
+// to NewVirtualAxis, and returns the value of the virtual axis. Missing values
+// are treated as centered, and extra values are ignored.
+func (va *VirtualAxis) Update(inputs ...int) int {
+	center := va.center()
+
+	value := center
+	for i, in := range va.inputs {
+		if i < len(inputs) {
+			value += va.scale(in, inputs[i]) - center
+		}
+	}
+	va.value = va.clamp(value)
+
+	if va.recenter {
+		// Hold the trimmed value until the axis returns to its center
+		if (va.releaseAt > center && va.value > center) ||
+			(va.releaseAt < center && va.value < center) {
+			return va.clamp(center + va.trim)
+		}
+		va.recenter = false
+	}
+
+	return va.clamp(va.value + va.trim)
+}
+
+// SetTrimHeld reports whether the trim button is held. Releasing the button
+// adds the offset of the axis from its center to the trim.
+func (va *VirtualAxis) SetTrimHeld(held bool) {
+	if held == va.trimHeld {
+		return
+	}
+
+	va.trimHeld = held
+	if held {
+		va.recenter = false
+		return
+	}
+
+	center := va.center()
+	va.trim = va.clamp(va.value+va.trim) - center
+	va.releaseAt = va.value
+	va.recenter = va.value != center
+}
+
+// Trim returns the offset added to the virtual axis by the trim
+func (va *VirtualAxis) Trim() int {
+	return va.trim
+}
+
+// ResetTrim removes the trim
+func (va *VirtualAxis) ResetTrim() {
+	va.trim = 0
+	va.recenter = false
+}
+
+// center returns the center value of the virtual axis
+func (va *VirtualAxis) center() int {
+	return (va.min + va.max) / 2
+}
+
+// scale converts the value of the physical axis to the range of the virtual
+// axis
+func (va *VirtualAxis) scale(in AxisInput, value int) int {
+	if value < in.Min {
+		value = in.Min
+	}
+	if value > in.Max {
+		value = in.Max
+	}
+	if in.Invert {
+		value = in.Min + in.Max - value
+	}
+
+	return va.min + (value-in.Min)*(va.max-va.min)/(in.Max-in.Min)
+}
+
+// clamp limits the value to the range of the virtual axis
+func (va *VirtualAxis) clamp(value int) int {
+	if value < va.min {
+		return va.min
+	}
+	if value > va.max {
+		return va.max
+	}
+
+	return value
+}
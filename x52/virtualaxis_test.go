@@ -0,0 +1,139 @@
+package x52
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVirtualAxisSplit(t *testing.T) {
+	// Split the throttle into two engines, each using half of its travel
+	left, err := NewVirtualAxis(0, 100, AxisInput{Min: 0, Max: 127})
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	right, _ := NewVirtualAxis(0, 100, AxisInput{Min: 128, Max: 255})
+
+	for _, tc := range []struct {
+		throttle    int
+		left, right int
+	}{
+		{0, 0, 0},
+		{127, 100, 0},
+		{128, 100, 0},
+		{255, 100, 100},
+	} {
+		if got := left.Update(tc.throttle); got != tc.left {
+			t.Errorf("Throttle %d: expected left %d, got %d", tc.throttle, tc.left, got)
+		}
+		if got := right.Update(tc.throttle); got != tc.right {
+			t.Errorf("Throttle %d: expected right %d, got %d", tc.throttle, tc.right, got)
+		}
+	}
+}
+
+func TestVirtualAxisCombine(t *testing.T) {
+	// Rudder from the twist and an inverted rotary
+	rudder, err := NewVirtualAxis(-100, 100,
+		AxisInput{Min: 0, Max: 200},
+		AxisInput{Min: 0, Max: 200, Invert: true})
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	for _, tc := range []struct {
+		twist, rotary int
+		expected      int
+	}{
+		{100, 100, 0},
+		{150, 100, 50},
+		{150, 50, 100},
+		{200, 0, 100},
+		{100, 150, -50},
+		{300, 100, 100},
+	} {
+		if got := rudder.Update(tc.twist, tc.rotary); got != tc.expected {
+			t.Errorf("Inputs %d, %d: expected %d, got %d", tc.twist, tc.rotary, tc.expected, got)
+		}
+	}
+
+	// Missing inputs are centered
+	if got := rudder.Update(150); got != 50 {
+		t.Errorf("Expected 50 with a missing input, got %d", got)
+	}
+
+	for _, inputs := range [][]AxisInput{
+		nil,
+		{{Min: 10, Max: 10}},
+	} {
+		if _, err := NewVirtualAxis(0, 100, inputs...); err == nil {
+			t.Errorf("Expected error for inputs %v", inputs)
+		}
+	}
+	if _, err := NewVirtualAxis(100, 0, AxisInput{Min: 0, Max: 100}); err == nil {
+		t.Error("Expected error for inverted range")
+	}
+}
+
+func TestVirtualAxisTrim(t *testing.T) {
+	va, _ := NewVirtualAxis(-100, 100, AxisInput{Min: -100, Max: 100})
+
+	va.SetTrimHeld(true)
+	for _, tc := range []struct {
+		input, expected int
+	}{
+		{0, 0},
+		{30, 30},
+	} {
+		if got := va.Update(tc.input); got != tc.expected {
+			t.Errorf("Trimming %d: expected %d, got %d", tc.input, tc.expected, got)
+		}
+	}
+
+	// The value is held after release until the axis is centered again
+	va.SetTrimHeld(false)
+	if va.Trim() != 30 {
+		t.Errorf("Expected trim 30, got %d", va.Trim())
+	}
+	for _, tc := range []struct {
+		input, expected int
+	}{
+		{30, 30},
+		{10, 30},
+		{0, 30},
+		{20, 50},
+		{-20, 10},
+		{90, 100},
+	} {
+		if got := va.Update(tc.input); got != tc.expected {
+			t.Errorf("Input %d: expected %d, got %d", tc.input, tc.expected, got)
+		}
+	}
+
+	va.ResetTrim()
+	if got := va.Update(20); got != 20 {
+		t.Errorf("Expected 20 after reset, got %d", got)
+	}
+}
+
+func TestVirtualAxisZones(t *testing.T) {
+	ctx := NewContext()
+	defer ctx.Close()
+	bitSet(&ctx.featureFlags, FeatureLED)
+
+	// Light the throttle LED when the right engine of a split throttle is
+	// past the afterburner detent
+	right, _ := NewVirtualAxis(0, 100, AxisInput{Min: 128, Max: 255})
+	az := NewAxisZones(ctx)
+	az.Add(AxisZone{Name: "ab", Min: 90, Max: 100, LED: LedThrottle, LedState: LedOn})
+
+	events, err := az.Update(right.Update(250))
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	if exp := []ZoneEvent{{"ab", true}}; !reflect.DeepEqual(events, exp) {
+		t.Errorf("Expected %v, got %v", exp, events)
+	}
+	if got := ledState(ctx, LedThrottle); got != LedOn {
+		t.Errorf("Expected throttle LED on, got %v", got)
+	}
+}